@@ -0,0 +1,185 @@
+// Command balance plays many seeded headless games per difficulty with the autopilot
+// and reports how hard each setting is, optionally searching a scale for a target win rate.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+
+	"block-game/internal/application"
+	"block-game/pkg/config"
+	"block-game/pkg/domain"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "balance:", err)
+		os.Exit(1)
+	}
+}
+
+type options struct {
+	runs         int
+	seed         int64
+	skill        string
+	difficulties []domain.Difficulty
+	format       string
+	maxSeconds   float64
+	workers      int
+
+	targets    map[domain.Difficulty]float64 // win rate to search for, per difficulty
+	param      string
+	lo, hi     float64
+	iterations int
+}
+
+func parseOptions(args []string) (options, error) {
+	var o options
+	var difficulties, targets string
+	fs := flag.NewFlagSet("balance", flag.ContinueOnError)
+	fs.IntVar(&o.runs, "runs", 100, "games per difficulty setting; run i uses seed+i")
+	fs.Int64Var(&o.seed, "seed", 1, "seed of the first game")
+	fs.StringVar(&o.skill, "skill", application.SkillAverage.Name, "autopilot skill: novice, average, expert or perfect")
+	fs.StringVar(&difficulties, "difficulty", "all", "comma separated difficulties to evaluate, or all")
+	fs.StringVar(&o.format, "format", "markdown", "report format: markdown or csv")
+	fs.Float64Var(&o.maxSeconds, "max-seconds", 600, "simulated seconds before a game counts as unfinished")
+	fs.IntVar(&o.workers, "workers", runtime.NumCPU(), "games simulated in parallel")
+	fs.StringVar(&targets, "target", "", "win rates to search for, e.g. EASY=0.9,NORMAL=0.7,HARD=0.4")
+	fs.StringVar(&o.param, "param", string(application.ScaleBallSpeed), "scale searched with -target")
+	fs.Float64Var(&o.lo, "lo", 0.5, "lowest scale searched")
+	fs.Float64Var(&o.hi, "hi", 2.0, "highest scale searched")
+	fs.IntVar(&o.iterations, "iterations", 6, "bisection steps per search")
+	if err := fs.Parse(args); err != nil {
+		return o, err
+	}
+	if fs.NArg() > 0 {
+		return o, fmt.Errorf("unexpected arguments: %v", fs.Args())
+	}
+	if o.runs < 1 {
+		return o, errors.New("-runs must be at least 1")
+	}
+	if o.format != "markdown" && o.format != "csv" {
+		return o, fmt.Errorf("unknown format %q", o.format)
+	}
+	if o.maxSeconds <= 0 {
+		return o, errors.New("-max-seconds must be positive")
+	}
+
+	profile := domain.DefaultDifficultyProfile()
+	var err error
+	if o.difficulties, err = parseDifficulties(difficulties, profile); err != nil {
+		return o, err
+	}
+	if o.targets, err = parseTargets(targets, profile); err != nil {
+		return o, err
+	}
+	return o, nil
+}
+
+// parseDifficulties accepts "all" or a comma separated list, in profile order.
+func parseDifficulties(list string, profile domain.DifficultyProfile) ([]domain.Difficulty, error) {
+	if strings.EqualFold(strings.TrimSpace(list), "all") {
+		return sortedDifficulties(profile), nil
+	}
+	var out []domain.Difficulty
+	for _, name := range strings.Split(list, ",") {
+		d := domain.Difficulty(strings.ToUpper(strings.TrimSpace(name)))
+		if _, ok := profile.Settings[d]; !ok {
+			return nil, fmt.Errorf("unknown difficulty %q", name)
+		}
+		out = append(out, d)
+	}
+	return out, nil
+}
+
+func parseTargets(list string, profile domain.DifficultyProfile) (map[domain.Difficulty]float64, error) {
+	if list == "" {
+		return nil, nil
+	}
+	targets := map[domain.Difficulty]float64{}
+	for _, pair := range strings.Split(list, ",") {
+		name, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("target %q: want DIFFICULTY=RATE", pair)
+		}
+		d := domain.Difficulty(strings.ToUpper(strings.TrimSpace(name)))
+		if _, ok := profile.Settings[d]; !ok {
+			return nil, fmt.Errorf("unknown difficulty %q", name)
+		}
+		rate, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || rate < 0 || rate > 1 {
+			return nil, fmt.Errorf("target %q: rate must be between 0 and 1", pair)
+		}
+		targets[d] = rate
+	}
+	return targets, nil
+}
+
+// sortedDifficulties orders the profile from easiest to hardest by ball speed.
+func sortedDifficulties(profile domain.DifficultyProfile) []domain.Difficulty {
+	out := make([]domain.Difficulty, 0, len(profile.Settings))
+	for d := range profile.Settings {
+		out = append(out, d)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		a, b := profile.Settings[out[i]], profile.Settings[out[j]]
+		if a.BallSpeedScale != b.BallSpeedScale {
+			return a.BallSpeedScale < b.BallSpeedScale
+		}
+		return out[i] < out[j]
+	})
+	return out
+}
+
+func run(args []string, stdout io.Writer) error {
+	o, err := parseOptions(args)
+	if err != nil {
+		return err
+	}
+	skill, err := application.SkillByName(o.skill)
+	if err != nil {
+		return err
+	}
+	base := config.DefaultLayoutConfig()
+	cfg := application.BalanceConfig{
+		Base:     base,
+		Runs:     o.runs,
+		Seed:     o.seed,
+		Skill:    skill,
+		MaxTicks: max(base.DurationTicks(o.maxSeconds), 1),
+		Workers:  o.workers,
+	}
+
+	profile := domain.DefaultDifficultyProfile()
+	var reports []application.BalanceReport
+	var searches []application.SearchResult
+	for _, d := range o.difficulties {
+		setting := profile.Settings[d]
+		if target, ok := o.targets[d]; ok {
+			res, err := application.SearchScale(cfg, setting, application.ScaleParam(o.param), target, o.lo, o.hi, o.iterations)
+			if err != nil {
+				return err
+			}
+			searches = append(searches, res)
+			reports = append(reports, res.Report)
+			continue
+		}
+		report, err := application.Evaluate(cfg, setting)
+		if err != nil {
+			return err
+		}
+		reports = append(reports, report)
+	}
+
+	if o.format == "csv" {
+		return writeCSV(stdout, reports, searches)
+	}
+	return writeMarkdown(stdout, cfg, reports, searches)
+}
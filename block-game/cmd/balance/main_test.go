@@ -0,0 +1,60 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+)
+
+func TestRunMarkdownReport(t *testing.T) {
+	var out bytes.Buffer
+	if err := run([]string{"-runs", "3", "-max-seconds", "60", "-workers", "2"}, &out); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	text := out.String()
+	easy, normal, hard := strings.Index(text, "| EASY |"), strings.Index(text, "| NORMAL |"), strings.Index(text, "| HARD |")
+	if easy < 0 || normal < easy || hard < normal {
+		t.Fatalf("expected one row per difficulty, easiest first:\n%s", text)
+	}
+	if strings.Contains(text, "Scale search") {
+		t.Fatalf("no search was requested:\n%s", text)
+	}
+}
+
+func TestRunCSVWithSearch(t *testing.T) {
+	var out bytes.Buffer
+	args := []string{"-runs", "2", "-max-seconds", "30", "-difficulty", "hard", "-format", "csv",
+		"-target", "HARD=0.5", "-param", "paddle-width", "-iterations", "1"}
+	if err := run(args, &out); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	rows, err := csv.NewReader(&out).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid CSV: %v", err)
+	}
+	if len(rows) != 2 || rows[1][0] != "HARD" {
+		t.Fatalf("expected a header and one HARD row, got %v", rows)
+	}
+	last := len(rows[1]) - 1
+	if rows[1][last-1] != "paddle-width" || rows[1][last] != "0.5" {
+		t.Fatalf("expected the search columns to be filled, got %v", rows[1])
+	}
+}
+
+func TestParseOptionsErrors(t *testing.T) {
+	tests := [][]string{
+		{"-runs", "0"},
+		{"-format", "html"},
+		{"-difficulty", "INSANE"},
+		{"-target", "NORMAL"},
+		{"-target", "NORMAL=1.5"},
+		{"-skill", "godlike"},
+		{"-runs", "1", "-difficulty", "NORMAL", "-target", "NORMAL=0.5", "-param", "gravity"},
+	}
+	for _, args := range tests {
+		if err := run(args, &bytes.Buffer{}); err == nil {
+			t.Errorf("expected an error for %v", args)
+		}
+	}
+}
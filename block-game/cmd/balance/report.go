@@ -0,0 +1,72 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+
+	"block-game/internal/application"
+)
+
+func writeMarkdown(w io.Writer, cfg application.BalanceConfig, reports []application.BalanceReport, searches []application.SearchResult) error {
+	fmt.Fprintf(w, "# Difficulty balance\n\n")
+	fmt.Fprintf(w, "%d games per setting, seeds %d..%d, autopilot skill %s.\n\n",
+		cfg.Runs, cfg.Seed, cfg.Seed+int64(cfg.Runs)-1, cfg.Skill.Name)
+
+	fmt.Fprintln(w, "| Difficulty | Ball speed | Paddle width | Block count | Win rate | Clear time mean (s) | p50 (s) | p90 (s) | Balls lost/min | Item pickup | Mean score |")
+	fmt.Fprintln(w, "|---|---:|---:|---:|---:|---:|---:|---:|---:|---:|---:|")
+	for _, r := range reports {
+		s := r.Setting
+		fmt.Fprintf(w, "| %s | %.2f | %.2f | %.2f | %.0f%% | %.1f | %.1f | %.1f | %.2f | %.0f%% | %.1f |\n",
+			s.Name, s.BallSpeedScale, s.PaddleWidthScale, s.BlockCountScale,
+			r.WinRate*100, r.ClearTimeMean, r.ClearTimeP50, r.ClearTimeP90,
+			r.BallLossRate, r.ItemPickupRate*100, r.MeanScore)
+	}
+
+	if len(searches) > 0 {
+		fmt.Fprintln(w, "\n## Scale search")
+		fmt.Fprintln(w, "\n| Difficulty | Scale | Target win rate | Found scale | Win rate | Settings tried | Note |")
+		fmt.Fprintln(w, "|---|---|---:|---:|---:|---:|---|")
+		for _, s := range searches {
+			note := "bracketed"
+			if !s.Reached {
+				note = "target outside the searched range"
+			}
+			fmt.Fprintf(w, "| %s | %s | %.0f%% | %.3f | %.0f%% | %d | %s |\n",
+				s.Report.Setting.Name, s.Param, s.Target*100, s.Scale, s.Report.WinRate*100, s.Searched, note)
+		}
+	}
+	return nil
+}
+
+func writeCSV(w io.Writer, reports []application.BalanceReport, searches []application.SearchResult) error {
+	searched := map[string]application.SearchResult{}
+	for _, s := range searches {
+		searched[string(s.Report.Setting.Name)] = s
+	}
+
+	cw := csv.NewWriter(w)
+	cw.Write([]string{
+		"difficulty", "ball_speed_scale", "ball_radius_scale", "paddle_width_scale", "paddle_speed_scale",
+		"block_size_scale", "block_count_scale", "runs", "wins", "win_rate", "clear_time_mean",
+		"clear_time_p50", "clear_time_p90", "balls_lost_per_min", "item_pickup_rate", "mean_score",
+		"searched_param", "target_win_rate",
+	})
+	f := func(v float64) string { return strconv.FormatFloat(v, 'f', -1, 64) }
+	for _, r := range reports {
+		s := r.Setting
+		param, target := "", ""
+		if res, ok := searched[string(s.Name)]; ok {
+			param, target = string(res.Param), f(res.Target)
+		}
+		cw.Write([]string{
+			string(s.Name), f(s.BallSpeedScale), f(s.BallRadiusScale), f(s.PaddleWidthScale), f(s.PaddleSpeedScale),
+			f(s.BlockSizeScale), f(s.BlockCountScale), strconv.Itoa(r.Runs), strconv.Itoa(r.Wins), f(r.WinRate),
+			f(r.ClearTimeMean), f(r.ClearTimeP50), f(r.ClearTimeP90), f(r.BallLossRate), f(r.ItemPickupRate),
+			f(r.MeanScore), param, target,
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,247 @@
+// Command headless runs games without a window and prints their results,
+// for balancing and regression checks on machines without a display.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"block-game/internal/application"
+	"block-game/pkg/config"
+	"block-game/pkg/domain"
+	"block-game/pkg/level"
+	"block-game/pkg/replay"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "headless:", err)
+		os.Exit(1)
+	}
+}
+
+type options struct {
+	runs       int
+	difficulty string
+	seed       int64
+	levelPath  string
+	stage      int
+	input      string
+	skill      string
+	scriptPath string
+	replayPath string
+	maxSeconds float64
+	format     string
+}
+
+func parseOptions(args []string) (options, error) {
+	var o options
+	fs := flag.NewFlagSet("headless", flag.ContinueOnError)
+	fs.IntVar(&o.runs, "n", 1, "number of games to run; run i uses seed+i")
+	fs.StringVar(&o.difficulty, "difficulty", string(domain.DifficultyNormal), "EASY, NORMAL or HARD")
+	fs.Int64Var(&o.seed, "seed", 1, "seed of the first run")
+	fs.StringVar(&o.levelPath, "level", "", "level JSON file to play instead of generated blocks")
+	fs.IntVar(&o.stage, "stage", 0, "bundled campaign stage to play (1-based; 0 generates blocks)")
+	fs.StringVar(&o.input, "input", "bot", "input source: bot (autopilot), follow (simple bot), script or replay")
+	fs.StringVar(&o.skill, "skill", application.SkillExpert.Name, "autopilot skill for -input bot: novice, average, expert or perfect")
+	fs.StringVar(&o.scriptPath, "script", "", "input script for -input script")
+	fs.StringVar(&o.replayPath, "replay", "", "replay file for -input replay (its layout and seed are used)")
+	fs.Float64Var(&o.maxSeconds, "max-seconds", 600, "simulated seconds before a run is stopped as unfinished")
+	fs.StringVar(&o.format, "format", "text", "output format: text or json")
+	if err := fs.Parse(args); err != nil {
+		return o, err
+	}
+	if fs.NArg() > 0 {
+		return o, fmt.Errorf("unexpected arguments: %v", fs.Args())
+	}
+	if o.runs < 1 {
+		return o, errors.New("-n must be at least 1")
+	}
+	if o.levelPath != "" && o.stage != 0 {
+		return o, errors.New("-level and -stage are mutually exclusive")
+	}
+	if o.format != "text" && o.format != "json" {
+		return o, fmt.Errorf("unknown format %q", o.format)
+	}
+	if o.maxSeconds <= 0 {
+		return o, errors.New("-max-seconds must be positive")
+	}
+	return o, nil
+}
+
+func run(args []string, stdout io.Writer) error {
+	o, err := parseOptions(args)
+	if err != nil {
+		return err
+	}
+	runs, err := plan(o)
+	if err != nil {
+		return err
+	}
+	results := make([]application.RunResult, 0, len(runs))
+	for _, r := range runs {
+		result, err := application.RunHeadless(r)
+		if err != nil {
+			return err
+		}
+		results = append(results, result)
+	}
+	if o.format == "json" {
+		return writeJSON(stdout, results)
+	}
+	return writeText(stdout, results)
+}
+
+// plan builds the runs described by the options.
+func plan(o options) ([]application.HeadlessRun, error) {
+	if o.input == "replay" {
+		return planReplay(o)
+	}
+
+	lvl, err := loadLevel(o)
+	if err != nil {
+		return nil, err
+	}
+	newInput, err := inputFactory(o)
+	if err != nil {
+		return nil, err
+	}
+
+	runs := make([]application.HeadlessRun, o.runs)
+	for i := range runs {
+		layout, err := layoutFor(lvl, o.difficulty)
+		if err != nil {
+			return nil, err
+		}
+		seed := o.seed + int64(i)
+		layout.Seed = &seed
+		runs[i] = application.HeadlessRun{
+			Layout:   layout,
+			Level:    lvl,
+			Input:    newInput(seed),
+			MaxTicks: maxTicks(layout, o.maxSeconds),
+		}
+	}
+	return runs, nil
+}
+
+func planReplay(o options) ([]application.HeadlessRun, error) {
+	if o.replayPath == "" {
+		return nil, errors.New("-input replay needs -replay")
+	}
+	rep, err := replay.Load(o.replayPath)
+	if err != nil {
+		return nil, err
+	}
+	if rep.Mode != replay.ModeStage {
+		return nil, fmt.Errorf("only %s replays can be run headless, got %s", replay.ModeStage, rep.Mode)
+	}
+	layout := rep.Layout
+	seed := rep.Seed
+	layout.Seed = &seed
+	return []application.HeadlessRun{{
+		Layout:   layout,
+		Input:    application.NewReplayInput(rep.Inputs),
+		MaxTicks: max(len(rep.Inputs), 1),
+	}}, nil
+}
+
+func loadLevel(o options) (*level.Level, error) {
+	switch {
+	case o.levelPath != "":
+		return level.LoadFile(o.levelPath, config.DefaultLayoutConfig())
+	case o.stage != 0:
+		levels, err := level.Campaign(config.DefaultLayoutConfig())
+		if err != nil {
+			return nil, err
+		}
+		if o.stage < 1 || o.stage > len(levels) {
+			return nil, fmt.Errorf("-stage must be between 1 and %d", len(levels))
+		}
+		return levels[o.stage-1], nil
+	}
+	return nil, nil
+}
+
+func layoutFor(lvl *level.Level, difficulty string) (domain.LayoutConfig, error) {
+	var layout domain.LayoutConfig
+	var applied domain.Difficulty
+	var err error
+	if lvl != nil {
+		layout, applied, err = config.LayoutForLevel(lvl, difficulty)
+	} else {
+		layout, applied, err = config.LayoutWithDifficulty(difficulty)
+	}
+	if err != nil {
+		return layout, fmt.Errorf("difficulty %q: %w (would fall back to %s)", difficulty, err, applied)
+	}
+	return layout, nil
+}
+
+// inputFactory returns a constructor for a fresh input per run, given the run's seed.
+func inputFactory(o options) (func(seed int64) application.InputPort, error) {
+	switch o.input {
+	case "bot":
+		skill, err := application.SkillByName(o.skill)
+		if err != nil {
+			return nil, err
+		}
+		return func(seed int64) application.InputPort { return application.NewAutopilot(skill, seed) }, nil
+	case "follow":
+		return func(int64) application.InputPort { return application.NewFollowBot() }, nil
+	case "script":
+		if o.scriptPath == "" {
+			return nil, errors.New("-input script needs -script")
+		}
+		f, err := os.Open(o.scriptPath)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		script, err := application.ParseScript(f)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", o.scriptPath, err)
+		}
+		return func(int64) application.InputPort { return script.Clone() }, nil
+	}
+	return nil, fmt.Errorf("unknown input source %q", o.input)
+}
+
+func maxTicks(layout domain.LayoutConfig, seconds float64) int {
+	return max(layout.DurationTicks(seconds), 1)
+}
+
+func writeJSON(w io.Writer, results []application.RunResult) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(struct {
+		Runs    []application.RunResult `json:"runs"`
+		Summary application.RunSummary  `json:"summary"`
+	}{results, application.Summarize(results)})
+}
+
+func writeText(w io.Writer, results []application.RunResult) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "SEED\tDIFFICULTY\tLEVEL\tOUTCOME\tSCORE\tTICKS\tSECONDS\tLIVES\tBALLS LOST\tITEMS\tBLOCKS")
+	for _, r := range results {
+		lvl := r.Level
+		if lvl == "" {
+			lvl = "-"
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%d\t%d\t%.1f\t%d\t%d\t%d/%d\t%d\n",
+			r.Seed, r.Difficulty, lvl, r.Outcome, r.Score, r.Ticks, r.Seconds,
+			r.LivesLeft, r.BallsLost, r.ItemsCollected, r.ItemsDropped, r.BlocksDestroyed)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	s := application.Summarize(results)
+	_, err := fmt.Fprintf(w, "\n%d runs: %d cleared, %d lost, %d unfinished; mean score %.1f, best %d, mean ticks %.0f\n",
+		s.Runs, s.Cleared, s.Lost, s.Unfinished, s.MeanScore, s.BestScore, s.MeanTicks)
+	return err
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"block-game/internal/application"
+	"block-game/pkg/config"
+	"block-game/pkg/replay"
+)
+
+func TestRunTextOutput(t *testing.T) {
+	var out bytes.Buffer
+	if err := run([]string{"-n", "2", "-seed", "5", "-max-seconds", "5"}, &out); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	text := out.String()
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+	if len(lines) != 5 || !strings.HasPrefix(lines[1], "5 ") || !strings.HasPrefix(lines[2], "6 ") {
+		t.Fatalf("expected a header, two runs and a summary, got:\n%s", text)
+	}
+	if !strings.Contains(text, "2 runs:") {
+		t.Fatalf("missing summary:\n%s", text)
+	}
+}
+
+func TestRunJSONOutputWithStageAndScript(t *testing.T) {
+	script := filepath.Join(t.TempDir(), "serve.txt")
+	if err := os.WriteFile(script, []byte("launch\nwait 59\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	err := run([]string{"-stage", "1", "-difficulty", "HARD", "-input", "script", "-script", script, "-format", "json"}, &out)
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	var report struct {
+		Runs    []application.RunResult
+		Summary application.RunSummary
+	}
+	if err := json.Unmarshal(out.Bytes(), &report); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out.String())
+	}
+	r := report.Runs[0]
+	if len(report.Runs) != 1 || r.Level != "First Steps" || r.Difficulty != "HARD" || r.Ticks != 60 {
+		t.Fatalf("unexpected result: %+v", report.Runs)
+	}
+	if report.Summary.Runs != 1 || report.Summary.Unfinished != 1 {
+		t.Fatalf("unexpected summary: %+v", report.Summary)
+	}
+}
+
+func TestRunReplayInput(t *testing.T) {
+	cfg := config.DefaultLayoutConfig()
+	bot := application.NewFollowBot()
+	recorded, recorder, err := application.NewRecordedGame(cfg, bot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bot.Observe(recorded.State(), recorded.Layout())
+	for i := 0; i < 300; i++ {
+		recorded.Step()
+	}
+	rep, err := recorder.Replay(replay.ModeStage, recorded.Layout())
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "run.json")
+	if err := replay.Save(path, rep); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := run([]string{"-input", "replay", "-replay", path, "-format", "json"}, &out); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	var report struct{ Runs []application.RunResult }
+	if err := json.Unmarshal(out.Bytes(), &report); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if got := report.Runs[0]; got.Ticks != recorded.State().Tick || got.Score != recorded.State().Score {
+		t.Fatalf("replay run should match the recording: got %+v, recorded tick %d score %d",
+			got, recorded.State().Tick, recorded.State().Score)
+	}
+}
+
+func TestParseOptionsErrors(t *testing.T) {
+	tests := [][]string{
+		{"-n", "0"},
+		{"-level", "a.json", "-stage", "1"},
+		{"-format", "xml"},
+		{"-input", "joystick"},
+		{"-input", "script"},
+		{"-input", "replay"},
+		{"-stage", "99"},
+	}
+	for _, args := range tests {
+		if err := run(args, &bytes.Buffer{}); err == nil {
+			t.Errorf("expected an error for %v", args)
+		}
+	}
+}
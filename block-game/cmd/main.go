@@ -3,6 +3,8 @@ package main
 import (
 	"flag"
 	"log"
+	"os"
+	"path/filepath"
 
 	"block-game/internal/infrastructure/adapter"
 	"block-game/internal/infrastructure/input"
@@ -12,11 +14,27 @@ import (
 )
 
 func main() {
+	replayPath := flag.String("replay", defaultPath("last_replay.json"), "file the last run is saved to and watched from (empty disables replays)")
+	savePath := flag.String("save", defaultPath("save.json"), "file Save & Quit suspends the game to (empty disables saving)")
 	flag.Parse()
 
 	baseLayout := config.DefaultLayoutConfig()
 	inputPort := input.NewEbitenInputAdapter()
 	game := adapter.NewEbitenGame(inputPort)
+	if *replayPath != "" {
+		if err := os.MkdirAll(filepath.Dir(*replayPath), 0o755); err != nil {
+			log.Printf("replays disabled: %v", err)
+		} else {
+			game.SetReplayPath(*replayPath)
+		}
+	}
+	if *savePath != "" {
+		if err := os.MkdirAll(filepath.Dir(*savePath), 0o755); err != nil {
+			log.Printf("saving disabled: %v", err)
+		} else {
+			game.SetSavePath(*savePath)
+		}
+	}
 
 	ebiten.SetWindowSize(int(baseLayout.ScreenW), int(baseLayout.ScreenH))
 	ebiten.SetWindowTitle("Block Game - ブロック崩し")
@@ -25,3 +43,12 @@ func main() {
 		log.Fatal(err)
 	}
 }
+
+// defaultPath keeps the game's files next to the user's other configuration.
+func defaultPath(name string) string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "block-game", name)
+}
@@ -0,0 +1,262 @@
+package application
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+
+	"block-game/pkg/domain"
+)
+
+// Skill tunes how well the Autopilot plays.
+type Skill struct {
+	Name          string
+	ReactionTicks int     // steps between seeing the state and acting on it
+	Noise         float64 // standard deviation of the landing prediction error, in px
+}
+
+var (
+	SkillNovice  = Skill{Name: "novice", ReactionTicks: 15, Noise: 45}
+	SkillAverage = Skill{Name: "average", ReactionTicks: 8, Noise: 20}
+	SkillExpert  = Skill{Name: "expert", ReactionTicks: 2, Noise: 4}
+	SkillPerfect = Skill{Name: "perfect"}
+)
+
+// Skills lists the predefined skill levels from weakest to strongest.
+var Skills = []Skill{SkillNovice, SkillAverage, SkillExpert, SkillPerfect}
+
+// SkillByName looks up a predefined skill level, ignoring case.
+func SkillByName(name string) (Skill, error) {
+	names := make([]string, len(Skills))
+	for i, s := range Skills {
+		if strings.EqualFold(s.Name, name) {
+			return s, nil
+		}
+		names[i] = s.Name
+	}
+	return Skill{}, fmt.Errorf("unknown skill %q (want one of %s)", name, strings.Join(names, ", "))
+}
+
+// Autopilot is an InputPort that plays the game: it predicts where each ball reaches the
+// paddle, bouncing off the walls but ignoring blocks, guards the most urgent ball and
+// picks up falling items when there is time to spare.
+type Autopilot struct {
+	skill   Skill
+	rnd     *rand.Rand
+	state   *domain.GameState
+	layout  domain.LayoutConfig
+	pending []autopilotMove // decisions waiting out the reaction delay
+
+	noise     float64 // current prediction error
+	noiseBall int     // ball the error was drawn for; -1 when none
+}
+
+type autopilotMove struct {
+	target float64 // paddle centre to steer to
+	steer  bool
+	launch bool
+	fire   bool // hold fire while the laser is active
+}
+
+// NewAutopilot creates a bot of the given skill; seed makes its mistakes reproducible.
+func NewAutopilot(skill Skill, seed int64) *Autopilot {
+	return &Autopilot{
+		skill:     skill,
+		rnd:       rand.New(rand.NewSource(seed)),
+		noiseBall: -1,
+	}
+}
+
+func (a *Autopilot) Observe(state *domain.GameState, layout domain.LayoutConfig) {
+	a.state = state
+	a.layout = layout
+	a.pending = a.pending[:0]
+	a.noiseBall = -1
+}
+
+func (a *Autopilot) Read() domain.InputState {
+	if a.state == nil {
+		return domain.InputState{}
+	}
+	a.pending = append(a.pending, a.decide())
+	if len(a.pending) <= a.skill.ReactionTicks {
+		return domain.InputState{}
+	}
+	move := a.pending[0]
+	a.pending = a.pending[1:]
+
+	in := domain.InputState{Launch: move.launch, Fire: move.fire}
+	if !move.steer {
+		return in
+	}
+	paddle := a.state.Paddle
+	centre := paddle.X + paddle.Width/2
+	tolerance := math.Max(a.layout.PaddleSpeed*a.layout.StepSeconds(), 1)
+	switch {
+	case move.target < centre-tolerance:
+		in.MoveLeft = true
+	case move.target > centre+tolerance:
+		in.MoveRight = true
+	}
+	return counterSteer(a.state, in)
+}
+
+// decide picks what to do given the current state.
+func (a *Autopilot) decide() autopilotMove {
+	var move autopilotMove
+	_, move.fire = a.state.Effect(domain.EffectLaser)
+	ballIdx, ballX, ballT := -1, 0.0, math.Inf(1)
+	for i, ball := range a.state.Balls {
+		if ball.Attached {
+			move.launch = true
+			continue
+		}
+		x, t, ok := predictLanding(ball, a.state.Paddle.Y, a.layout.ScreenW)
+		if !ok {
+			continue
+		}
+		if ball.VY < 0 {
+			// a rising ball may come back off any block, so only shadow it
+			x = ball.X
+		}
+		if t < ballT || (t == ballT && ball.Y > a.state.Balls[ballIdx].Y) {
+			ballIdx, ballX, ballT = i, x, t
+		}
+	}
+
+	if ballIdx >= 0 {
+		if ballIdx != a.noiseBall {
+			a.noiseBall = ballIdx
+			a.noise = a.rnd.NormFloat64() * a.skill.Noise
+		}
+		ballX += a.noise
+	} else {
+		a.noiseBall = -1
+	}
+
+	if x, ok := a.itemTarget(ballIdx >= 0, ballX, ballT); ok {
+		return autopilotMove{target: a.dodge(x, ballIdx >= 0, ballX, ballT), steer: true, launch: move.launch}
+	}
+	target := ballX
+	if ballIdx < 0 {
+		paddle := a.state.Paddle
+		target = paddle.X + paddle.Width/2
+	}
+	if x := a.dodge(target, ballIdx >= 0, ballX, ballT); ballIdx >= 0 || x != target {
+		move.target = x
+		move.steer = true
+	}
+	return move
+}
+
+// harmful reports whether catching item would hurt, e.g. shrink the paddle.
+func harmful(item domain.Item) bool {
+	spec, ok := domain.ItemSpec(item.Type)
+	return ok && spec.Harmful
+}
+
+// itemTarget returns the centre of the earliest falling item the paddle can reach
+// without then missing the most urgent ball.
+func (a *Autopilot) itemTarget(hasBall bool, ballX, ballT float64) (float64, bool) {
+	paddle := a.state.Paddle
+	centre := paddle.X + paddle.Width/2
+	speed := a.layout.PaddleSpeed
+	if speed <= 0 {
+		return 0, false
+	}
+	// time the reaction delay costs on every plan
+	delay := float64(a.skill.ReactionTicks) * a.layout.StepSeconds()
+
+	best, bestT := 0.0, math.Inf(1)
+	for _, item := range a.state.Items {
+		if !item.Active || item.VY <= 0 || harmful(item) {
+			continue
+		}
+		t := (paddle.Y - item.Y - item.Height) / item.VY
+		if t < 0 {
+			continue
+		}
+		x := item.X + item.Width/2
+		if math.Abs(x-centre)/speed+delay > t {
+			continue
+		}
+		if hasBall && t+math.Abs(ballX-x)/speed+delay > ballT {
+			continue
+		}
+		if t < bestT {
+			best, bestT = x, t
+		}
+	}
+	return best, !math.IsInf(bestT, 1)
+}
+
+// dodge moves the paddle centre target aside when a harmful item would land on the
+// paddle there, unless stepping aside would then miss the most urgent ball.
+func (a *Autopilot) dodge(target float64, hasBall bool, ballX, ballT float64) float64 {
+	paddle := a.state.Paddle
+	half := paddle.Width / 2
+	speed := a.layout.PaddleSpeed
+	if speed <= 0 {
+		return target
+	}
+	delay := float64(a.skill.ReactionTicks) * a.layout.StepSeconds()
+
+	for _, item := range a.state.Items {
+		if !item.Active || item.VY <= 0 || !harmful(item) {
+			continue
+		}
+		t := (paddle.Y - item.Y - item.Height) / item.VY
+		if t < 0 || target+half < item.X || target-half > item.X+item.Width {
+			continue
+		}
+		// the nearer side that keeps the paddle on screen
+		left, right := item.X-half-1, item.X+item.Width+half+1
+		x := right
+		if left >= half && (target-left < right-target || right > a.layout.ScreenW-half) {
+			x = left
+		}
+		if hasBall && t+math.Abs(ballX-x)/speed+delay > ballT {
+			continue
+		}
+		target = x
+	}
+	return target
+}
+
+// predictLanding returns where and after how many seconds the ball's centre reaches the
+// height at which it touches the paddle, folding its path at the side walls and the
+// ceiling. Blocks are not taken into account.
+func predictLanding(ball domain.Ball, paddleY, screenW float64) (x, seconds float64, ok bool) {
+	if ball.VY == 0 {
+		return 0, 0, false
+	}
+	lineY := paddleY - ball.Radius
+	var dist float64
+	if ball.VY > 0 {
+		if ball.Y > lineY {
+			return ball.X, 0, true
+		}
+		dist = lineY - ball.Y
+	} else {
+		dist = (ball.Y - ball.Radius) + (lineY - ball.Radius)
+	}
+	seconds = dist / math.Abs(ball.VY)
+	return foldBetween(ball.X+ball.VX*seconds, ball.Radius, screenW-ball.Radius), seconds, true
+}
+
+// foldBetween reflects x back into [lo, hi] as if it bounced off both ends.
+func foldBetween(x, lo, hi float64) float64 {
+	w := hi - lo
+	if w <= 0 {
+		return lo
+	}
+	p := math.Mod(x-lo, 2*w)
+	if p < 0 {
+		p += 2 * w
+	}
+	if p > w {
+		p = 2*w - p
+	}
+	return lo + p
+}
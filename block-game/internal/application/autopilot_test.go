@@ -0,0 +1,173 @@
+package application
+
+import (
+	"math"
+	"testing"
+
+	"block-game/pkg/config"
+	"block-game/pkg/domain"
+)
+
+func TestPredictLanding(t *testing.T) {
+	const paddleY, screenW = 550.0, 800.0
+	tests := []struct {
+		name  string
+		ball  domain.Ball
+		wantX float64
+		wantT float64
+	}{
+		{"straight down", domain.Ball{X: 400, Y: 340, VY: 300, Radius: 10}, 400, 0.6667},
+		{"off the right wall", domain.Ball{X: 700, Y: 240, VX: 300, VY: 300, Radius: 10}, 580, 1},
+		{"off both walls", domain.Ball{X: 100, Y: 240, VX: -1500, VY: 300, Radius: 10}, 160, 1},
+		{"rising, off the ceiling", domain.Ball{X: 400, Y: 110, VX: 100, VY: -300, Radius: 10}, 610, 2.1},
+		{"already at the paddle", domain.Ball{X: 50, Y: 545, VY: 300, Radius: 10}, 50, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			x, sec, ok := predictLanding(tt.ball, paddleY, screenW)
+			if !ok {
+				t.Fatalf("expected a prediction")
+			}
+			if math.Abs(x-tt.wantX) > 1e-6 || math.Abs(sec-tt.wantT) > 1e-3 {
+				t.Fatalf("got x=%v t=%v, want x=%v t=%v", x, sec, tt.wantX, tt.wantT)
+			}
+		})
+	}
+	if _, _, ok := predictLanding(domain.Ball{X: 1, Y: 1, VX: 5}, paddleY, screenW); ok {
+		t.Fatalf("a ball without vertical speed never lands")
+	}
+}
+
+// autopilotState builds a game with the ball in flight for steering tests.
+func autopilotState(balls ...domain.Ball) (*domain.GameState, domain.LayoutConfig) {
+	cfg := config.DefaultLayoutConfig()
+	state := domain.NewGameState(cfg, nil)
+	for i := range balls {
+		balls[i].Speed = math.Hypot(balls[i].VX, balls[i].VY)
+	}
+	state.Balls = balls
+	return state, cfg
+}
+
+func TestAutopilotSteersTowardLanding(t *testing.T) {
+	// lands at x=580 after bouncing off the right wall, right of the centred paddle
+	state, cfg := autopilotState(domain.Ball{X: 700, Y: 240, VX: 300, VY: 300, Radius: 10})
+	bot := NewAutopilot(SkillPerfect, 1)
+	bot.Observe(state, cfg)
+	if in := bot.Read(); !in.MoveRight || in.MoveLeft {
+		t.Fatalf("expected to move right, got %+v", in)
+	}
+}
+
+func TestBotsCounterReversedControls(t *testing.T) {
+	state, cfg := autopilotState(domain.Ball{X: 700, Y: 240, VX: 300, VY: 300, Radius: 10})
+	domain.StartEffect(state, cfg, domain.EffectReverseControls)
+
+	pilot := NewAutopilot(SkillPerfect, 1)
+	pilot.Observe(state, cfg)
+	if in := pilot.Read(); !in.MoveLeft || in.MoveRight {
+		t.Fatalf("expected the autopilot to press left to go right, got %+v", in)
+	}
+	follow := NewFollowBot()
+	follow.Observe(state, cfg)
+	if in := follow.Read(); !in.MoveLeft || in.MoveRight {
+		t.Fatalf("expected the follow bot to press left to go right, got %+v", in)
+	}
+}
+
+func TestAutopilotGuardsMostUrgentBall(t *testing.T) {
+	state, cfg := autopilotState(
+		domain.Ball{X: 700, Y: 100, VX: 0, VY: 300, Radius: 10}, // far up
+		domain.Ball{X: 100, Y: 450, VX: 0, VY: 300, Radius: 10}, // about to land
+	)
+	bot := NewAutopilot(SkillPerfect, 1)
+	bot.Observe(state, cfg)
+	if in := bot.Read(); !in.MoveLeft {
+		t.Fatalf("expected to guard the lower ball on the left, got %+v", in)
+	}
+}
+
+func TestAutopilotCatchesItemsWhenSafe(t *testing.T) {
+	item := domain.Item{X: 560, Y: 400, Width: 16, Height: 12, VY: 180, Active: true}
+	// the ball is rising near the centre: plenty of time to grab the item
+	state, cfg := autopilotState(domain.Ball{X: 400, Y: 300, VY: -300, Radius: 10})
+	state.Items = []domain.Item{item}
+	bot := NewAutopilot(SkillPerfect, 1)
+	bot.Observe(state, cfg)
+	if in := bot.Read(); !in.MoveRight {
+		t.Fatalf("expected to go for the item, got %+v", in)
+	}
+
+	// a ball about to land on the left takes priority over the item
+	state.Balls = []domain.Ball{{X: 300, Y: 520, VY: 300, Radius: 10}}
+	bot.Observe(state, cfg)
+	if in := bot.Read(); !in.MoveLeft {
+		t.Fatalf("expected to save the ball instead, got %+v", in)
+	}
+}
+
+func TestAutopilotAvoidsHarmfulItems(t *testing.T) {
+	// the ball is rising over the centred paddle, and a shrink item falls onto it
+	shrink := domain.Item{X: 395, Y: 400, Width: 16, Height: 12, VY: 180, Active: true, Type: domain.ItemTypePaddleShrink}
+	state, cfg := autopilotState(domain.Ball{X: 400, Y: 300, VY: -300, Radius: 10})
+	state.Items = []domain.Item{shrink}
+	bot := NewAutopilot(SkillPerfect, 1)
+	bot.Observe(state, cfg)
+	if in := bot.Read(); !in.MoveLeft && !in.MoveRight {
+		t.Fatalf("expected to step aside from the shrink item, got %+v", in)
+	}
+
+	// one falling off to the side is left alone
+	shrink.X = 560
+	state.Items = []domain.Item{shrink}
+	bot.Observe(state, cfg)
+	if in := bot.Read(); in.MoveLeft || in.MoveRight {
+		t.Fatalf("expected to ignore the shrink item, got %+v", in)
+	}
+}
+
+func TestAutopilotReactionDelay(t *testing.T) {
+	state, cfg := autopilotState()
+	state.Balls = []domain.Ball{{X: 400, Y: 530, Radius: 10, Attached: true}}
+	bot := NewAutopilot(Skill{Name: "slow", ReactionTicks: 3}, 1)
+	bot.Observe(state, cfg)
+	for i := 0; i < 3; i++ {
+		if in := bot.Read(); in != (domain.InputState{}) {
+			t.Fatalf("step %d: should not act before the reaction delay, got %+v", i, in)
+		}
+	}
+	if in := bot.Read(); !in.Launch {
+		t.Fatalf("expected a launch after the delay, got %+v", in)
+	}
+}
+
+func TestSkillByName(t *testing.T) {
+	if s, err := SkillByName("Expert"); err != nil || s != SkillExpert {
+		t.Fatalf("expected the expert skill, got %+v %v", s, err)
+	}
+	if _, err := SkillByName("godlike"); err == nil {
+		t.Fatalf("expected an error for an unknown skill")
+	}
+}
+
+func TestAutopilotSkillLevelsRank(t *testing.T) {
+	meanScore := func(skill Skill) float64 {
+		total := 0
+		for seed := int64(1); seed <= 8; seed++ {
+			result, err := RunHeadless(HeadlessRun{
+				Layout:   seededLayout(seed),
+				Input:    NewAutopilot(skill, seed),
+				MaxTicks: 60 * 60 * 5,
+			})
+			if err != nil {
+				t.Fatalf("run: %v", err)
+			}
+			total += result.Score
+		}
+		return float64(total) / 8
+	}
+	novice, perfect := meanScore(SkillNovice), meanScore(SkillPerfect)
+	if perfect <= novice {
+		t.Fatalf("a perfect bot should outscore a novice: novice %.1f perfect %.1f", novice, perfect)
+	}
+}
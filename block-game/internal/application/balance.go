@@ -0,0 +1,239 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+
+	"block-game/pkg/domain"
+)
+
+// BalanceConfig describes a Monte Carlo evaluation of difficulty settings.
+type BalanceConfig struct {
+	Base     domain.LayoutConfig // layout before any difficulty is applied
+	Runs     int                 // games per setting; run i uses seed Seed+i
+	Seed     int64
+	Skill    Skill // autopilot skill standing in for the player
+	MaxTicks int   // per game; unfinished games count as not won
+	Workers  int   // games simulated in parallel (minimum 1)
+}
+
+// BalanceReport aggregates the games played with one difficulty setting.
+type BalanceReport struct {
+	Setting        domain.DifficultySetting
+	Runs           int
+	Wins           int
+	WinRate        float64
+	ClearTimeMean  float64 // seconds, over won games
+	ClearTimeP50   float64
+	ClearTimeP90   float64
+	BallLossRate   float64 // balls lost per minute of play
+	ItemPickupRate float64 // share of dropped items that were collected
+	MeanScore      float64
+}
+
+// Evaluate plays cfg.Runs seeded games with the setting and summarizes them.
+func Evaluate(cfg BalanceConfig, setting domain.DifficultySetting) (BalanceReport, error) {
+	if cfg.Runs <= 0 {
+		return BalanceReport{}, errors.New("runs must be positive")
+	}
+	layout, err := domain.ApplyDifficulty(cfg.Base, setting)
+	if err != nil {
+		return BalanceReport{}, fmt.Errorf("%s: %w", setting.Name, err)
+	}
+
+	results := make([]RunResult, cfg.Runs)
+	errs := make([]error, cfg.Runs)
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < max(cfg.Workers, 1); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				seed := cfg.Seed + int64(i)
+				run := layout
+				run.Seed = &seed
+				results[i], errs[i] = RunHeadless(HeadlessRun{
+					Layout:   run,
+					Input:    NewAutopilot(cfg.Skill, seed),
+					MaxTicks: cfg.MaxTicks,
+				})
+			}
+		}()
+	}
+	for i := range results {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	if err := errors.Join(errs...); err != nil {
+		return BalanceReport{}, err
+	}
+	return summarizeBalance(setting, results), nil
+}
+
+func summarizeBalance(setting domain.DifficultySetting, results []RunResult) BalanceReport {
+	r := BalanceReport{Setting: setting, Runs: len(results)}
+	var clearTimes []float64
+	var seconds float64
+	var lost, dropped, collected int
+	for _, res := range results {
+		if res.Outcome == OutcomeCleared {
+			r.Wins++
+			clearTimes = append(clearTimes, res.Seconds)
+		}
+		seconds += res.Seconds
+		lost += res.BallsLost
+		dropped += res.ItemsDropped
+		collected += res.ItemsCollected
+		r.MeanScore += float64(res.Score)
+	}
+	if r.Runs > 0 {
+		r.WinRate = float64(r.Wins) / float64(r.Runs)
+		r.MeanScore /= float64(r.Runs)
+	}
+	if len(clearTimes) > 0 {
+		sort.Float64s(clearTimes)
+		for _, t := range clearTimes {
+			r.ClearTimeMean += t
+		}
+		r.ClearTimeMean /= float64(len(clearTimes))
+		r.ClearTimeP50 = percentile(clearTimes, 50)
+		r.ClearTimeP90 = percentile(clearTimes, 90)
+	}
+	if seconds > 0 {
+		r.BallLossRate = float64(lost) / (seconds / 60)
+	}
+	if dropped > 0 {
+		r.ItemPickupRate = float64(collected) / float64(dropped)
+	}
+	return r
+}
+
+// percentile returns the nearest-rank percentile of sorted values.
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	return sorted[min(max(rank, 1), len(sorted))-1]
+}
+
+// ScaleParam names a DifficultySetting scale that SearchScale can tune.
+type ScaleParam string
+
+const (
+	ScaleBallSpeed   ScaleParam = "ball-speed"
+	ScaleBallRadius  ScaleParam = "ball-radius"
+	ScalePaddleWidth ScaleParam = "paddle-width"
+	ScalePaddleSpeed ScaleParam = "paddle-speed"
+	ScaleBlockSize   ScaleParam = "block-size"
+	ScaleBlockCount  ScaleParam = "block-count"
+)
+
+// ScaleParams lists the tunable scales.
+var ScaleParams = []ScaleParam{ScaleBallSpeed, ScaleBallRadius, ScalePaddleWidth, ScalePaddleSpeed, ScaleBlockSize, ScaleBlockCount}
+
+func (p ScaleParam) field(s *domain.DifficultySetting) (*float64, error) {
+	switch p {
+	case ScaleBallSpeed:
+		return &s.BallSpeedScale, nil
+	case ScaleBallRadius:
+		return &s.BallRadiusScale, nil
+	case ScalePaddleWidth:
+		return &s.PaddleWidthScale, nil
+	case ScalePaddleSpeed:
+		return &s.PaddleSpeedScale, nil
+	case ScaleBlockSize:
+		return &s.BlockSizeScale, nil
+	case ScaleBlockCount:
+		return &s.BlockCountScale, nil
+	}
+	names := make([]string, len(ScaleParams))
+	for i, n := range ScaleParams {
+		names[i] = string(n)
+	}
+	return nil, fmt.Errorf("unknown scale %q (want one of %s)", p, strings.Join(names, ", "))
+}
+
+// SearchResult is the outcome of SearchScale.
+type SearchResult struct {
+	Param    ScaleParam
+	Target   float64 // requested win rate
+	Scale    float64 // best scale found
+	Reached  bool    // the target lies within [lo, hi] and was bracketed
+	Report   BalanceReport
+	Searched int // settings evaluated
+}
+
+// SearchScale bisects one scale of setting within [lo, hi] for the value whose win rate
+// is closest to target, assuming the win rate changes monotonically with the scale.
+func SearchScale(cfg BalanceConfig, setting domain.DifficultySetting, param ScaleParam, target, lo, hi float64, iterations int) (SearchResult, error) {
+	if _, err := param.field(&setting); err != nil {
+		return SearchResult{}, err
+	}
+	if !(lo > 0 && hi > lo) {
+		return SearchResult{}, errors.New("search range must satisfy 0 < lo < hi")
+	}
+	result := SearchResult{Param: param, Target: target}
+	eval := func(scale float64) (BalanceReport, error) {
+		s := setting
+		field, _ := param.field(&s)
+		*field = scale
+		result.Searched++
+		return Evaluate(cfg, s)
+	}
+	scale, report, reached, err := bisectScale(lo, hi, target, iterations, eval)
+	if err != nil {
+		return SearchResult{}, err
+	}
+	result.Scale, result.Report, result.Reached = scale, report, reached
+	return result, nil
+}
+
+// bisectScale narrows [lo, hi] towards the scale whose win rate is closest to target.
+func bisectScale(lo, hi, target float64, iterations int, eval func(float64) (BalanceReport, error)) (float64, BalanceReport, bool, error) {
+	loRep, err := eval(lo)
+	if err != nil {
+		return 0, BalanceReport{}, false, err
+	}
+	hiRep, err := eval(hi)
+	if err != nil {
+		return 0, BalanceReport{}, false, err
+	}
+
+	best, bestRep := lo, loRep
+	consider := func(scale float64, rep BalanceReport) {
+		if math.Abs(rep.WinRate-target) < math.Abs(bestRep.WinRate-target) {
+			best, bestRep = scale, rep
+		}
+	}
+	consider(hi, hiRep)
+
+	// the target must lie between the win rates at the ends for bisection to apply
+	if (loRep.WinRate-target)*(hiRep.WinRate-target) > 0 {
+		return best, bestRep, false, nil
+	}
+	loAbove := loRep.WinRate > target
+	for i := 0; i < iterations; i++ {
+		mid := (lo + hi) / 2
+		rep, err := eval(mid)
+		if err != nil {
+			return 0, BalanceReport{}, false, err
+		}
+		consider(mid, rep)
+		if rep.WinRate == target {
+			break
+		}
+		if (rep.WinRate > target) == loAbove {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return best, bestRep, true, nil
+}
@@ -0,0 +1,100 @@
+package application
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"block-game/pkg/config"
+	"block-game/pkg/domain"
+)
+
+func testBalanceConfig(workers int) BalanceConfig {
+	return BalanceConfig{
+		Base:     config.DefaultLayoutConfig(),
+		Runs:     6,
+		Seed:     10,
+		Skill:    SkillAverage,
+		MaxTicks: 60 * 60 * 3,
+		Workers:  workers,
+	}
+}
+
+func TestEvaluateIsIndependentOfWorkers(t *testing.T) {
+	setting := domain.DefaultDifficultyProfile().Settings[domain.DifficultyNormal]
+	serial, err := Evaluate(testBalanceConfig(1), setting)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	parallel, err := Evaluate(testBalanceConfig(4), setting)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(serial, parallel) {
+		t.Fatalf("results should not depend on parallelism:\n%+v\n%+v", serial, parallel)
+	}
+	if serial.Runs != 6 || serial.Setting.Name != domain.DifficultyNormal || serial.MeanScore == 0 {
+		t.Fatalf("unexpected report: %+v", serial)
+	}
+}
+
+func TestSummarizeBalance(t *testing.T) {
+	r := summarizeBalance(domain.DifficultySetting{Name: "X"}, []RunResult{
+		{Outcome: OutcomeCleared, Seconds: 60, Score: 10, BallsLost: 1, ItemsDropped: 4, ItemsCollected: 3},
+		{Outcome: OutcomeCleared, Seconds: 120, Score: 20, BallsLost: 2, ItemsDropped: 4, ItemsCollected: 1},
+		{Outcome: OutcomeLost, Seconds: 30, Score: 3, BallsLost: 3},
+		{Outcome: OutcomeUnfinished, Seconds: 30, Score: 7},
+	})
+	if r.Runs != 4 || r.Wins != 2 || r.WinRate != 0.5 || r.MeanScore != 10 {
+		t.Fatalf("unexpected totals: %+v", r)
+	}
+	if r.ClearTimeMean != 90 || r.ClearTimeP50 != 60 || r.ClearTimeP90 != 120 {
+		t.Fatalf("unexpected clear times: %+v", r)
+	}
+	if r.BallLossRate != 1.5 || r.ItemPickupRate != 0.5 {
+		t.Fatalf("unexpected rates: %+v", r)
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	values := []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	for p, want := range map[float64]float64{0: 1, 10: 1, 50: 5, 90: 9, 91: 10, 100: 10} {
+		if got := percentile(values, p); got != want {
+			t.Errorf("p%v: got %v want %v", p, got, want)
+		}
+	}
+}
+
+func TestBisectScaleFindsTarget(t *testing.T) {
+	// win rate falls linearly from 1 at scale 0.5 to 0 at scale 1.5
+	calls := 0
+	eval := func(scale float64) (BalanceReport, error) {
+		calls++
+		return BalanceReport{WinRate: math.Max(0, math.Min(1, 1.5-scale))}, nil
+	}
+	scale, rep, reached, err := bisectScale(0.5, 2, 0.3, 10, eval)
+	if err != nil || !reached {
+		t.Fatalf("expected the target to be bracketed: %v", err)
+	}
+	if math.Abs(scale-1.2) > 0.01 || math.Abs(rep.WinRate-0.3) > 0.01 {
+		t.Fatalf("expected scale 1.2 for a 30%% win rate, got %v (%v)", scale, rep.WinRate)
+	}
+	if calls != 12 {
+		t.Fatalf("expected both ends plus 10 bisection steps, got %d evaluations", calls)
+	}
+
+	scale, _, reached, _ = bisectScale(0.5, 1, 0.1, 10, eval)
+	if reached || scale != 1 {
+		t.Fatalf("an unreachable target should return the closest end, got %v reached=%v", scale, reached)
+	}
+}
+
+func TestSearchScaleRejectsBadArguments(t *testing.T) {
+	setting := domain.DefaultDifficultyProfile().Settings[domain.DifficultyNormal]
+	if _, err := SearchScale(testBalanceConfig(1), setting, "gravity", 0.5, 0.5, 2, 3); err == nil {
+		t.Fatalf("expected an error for an unknown scale")
+	}
+	if _, err := SearchScale(testBalanceConfig(1), setting, ScaleBallSpeed, 0.5, 2, 1, 3); err == nil {
+		t.Fatalf("expected an error for an empty range")
+	}
+}
@@ -0,0 +1,74 @@
+package application
+
+import "block-game/pkg/domain"
+
+// StateObserver is implemented by inputs that decide from the game state, such as bots.
+// Runners hand them the state and layout of the game they drive.
+type StateObserver interface {
+	Observe(state *domain.GameState, layout domain.LayoutConfig)
+}
+
+// FollowBot is a simple InputPort that launches at once and keeps the paddle under
+// the lowest ball that is falling.
+type FollowBot struct {
+	state *domain.GameState
+}
+
+func NewFollowBot() *FollowBot {
+	return &FollowBot{}
+}
+
+func (b *FollowBot) Observe(state *domain.GameState, _ domain.LayoutConfig) {
+	b.state = state
+}
+
+func (b *FollowBot) Read() domain.InputState {
+	if b.state == nil || len(b.state.Balls) == 0 {
+		return domain.InputState{}
+	}
+	var in domain.InputState
+	_, in.Fire = b.state.Effect(domain.EffectLaser)
+	target := -1
+	for i, ball := range b.state.Balls {
+		if ball.Attached {
+			in.Launch = true
+			continue
+		}
+		if target < 0 || followPriority(ball, b.state.Balls[target]) {
+			target = i
+		}
+	}
+	if target < 0 {
+		return in
+	}
+
+	paddle := b.state.Paddle
+	centre := paddle.X + paddle.Width/2
+	deadZone := paddle.Width / 4
+	x := b.state.Balls[target].X
+	switch {
+	case x < centre-deadZone:
+		in.MoveLeft = true
+	case x > centre+deadZone:
+		in.MoveRight = true
+	}
+	return counterSteer(b.state, in)
+}
+
+// counterSteer swaps the moves while the controls are reversed, so that the paddle
+// still heads where the bot wants it.
+func counterSteer(state *domain.GameState, in domain.InputState) domain.InputState {
+	if _, ok := state.Effect(domain.EffectReverseControls); ok {
+		in.MoveLeft, in.MoveRight = in.MoveRight, in.MoveLeft
+	}
+	return in
+}
+
+// followPriority reports whether ball a should be followed rather than b:
+// falling balls come first, then the lowest one.
+func followPriority(a, b domain.Ball) bool {
+	if (a.VY > 0) != (b.VY > 0) {
+		return a.VY > 0
+	}
+	return a.Y > b.Y
+}
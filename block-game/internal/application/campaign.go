@@ -0,0 +1,154 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"block-game/pkg/domain"
+	"block-game/pkg/level"
+)
+
+var ErrNoLevels = errors.New("campaign has no levels")
+
+// LayoutFunc builds the layout for a level, e.g. config.LayoutForLevel with the selected difficulty.
+type LayoutFunc func(lvl *level.Level) (domain.LayoutConfig, error)
+
+// Campaign plays a sequence of levels, carrying score, lives and upgrades from one stage
+// to the next and ramping the difficulty per stage.
+type Campaign struct {
+	levels   []*level.Level
+	layout   LayoutFunc
+	rules    domain.StageRules
+	input    InputPort
+	rnd      domain.RandomSource
+	stage    int
+	game     *GameUsecase
+	upgrades domain.Upgrades
+
+	startScore int // score and lives when the current stage began
+	startLives int
+	tally      *domain.StageTally
+
+	subscribers []EventSubscriber // attached to every stage's game
+}
+
+func NewCampaign(levels []*level.Level, layout LayoutFunc, rules domain.StageRules, rnd domain.RandomSource, input InputPort) (*Campaign, error) {
+	if input == nil {
+		return nil, ErrNilInputPort
+	}
+	if len(levels) == 0 {
+		return nil, ErrNoLevels
+	}
+	c := &Campaign{
+		levels: levels,
+		layout: layout,
+		rules:  rules,
+		input:  input,
+		rnd:    rnd,
+	}
+	if err := c.load(0, 0, 0); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// load starts stage with the carried score and lives (lives <= 0 keeps the layout's default).
+func (c *Campaign) load(stage, score, lives int) error {
+	lvl := c.levels[stage]
+	layout, err := c.layout(lvl)
+	if err != nil {
+		return fmt.Errorf("stage %d (%s): %w", stage+1, lvl.Name, err)
+	}
+	layout = c.rules.Ramp(layout, stage, c.upgrades)
+	if lives > 0 {
+		layout.Lives = lives
+	}
+	game, err := NewGameUsecaseFromLevel(lvl, layout, c.rnd, c.input)
+	if err != nil {
+		return fmt.Errorf("stage %d (%s): %w", stage+1, lvl.Name, err)
+	}
+	game.state.Score = score
+	game.capturePrevious()
+	for _, s := range c.subscribers {
+		game.Subscribe(s)
+	}
+
+	c.stage = stage
+	c.game = game
+	c.startScore = score
+	c.startLives = game.state.Lives
+	c.tally = nil
+	return nil
+}
+
+// Update advances the current stage and records a tally once it is cleared.
+func (c *Campaign) Update(elapsed time.Duration) error {
+	if err := c.game.Update(elapsed); err != nil {
+		return err
+	}
+	state := c.game.State()
+	if state.Cleared && c.tally == nil {
+		// count losses, not the change in lives, which an extra life can hide
+		t := c.rules.Tally(c.stage, state.Score-c.startScore, state.Lives, state.Stats.LivesLost, &c.upgrades)
+		c.game.awardBonus(t.Bonus())
+		c.tally = &t
+	}
+	return nil
+}
+
+// NextStage loads the following level, carrying score and lives. It fails unless the
+// current stage has been cleared and another stage remains.
+func (c *Campaign) NextStage() error {
+	if c.tally == nil {
+		return errors.New("current stage is not cleared")
+	}
+	if c.Completed() {
+		return errors.New("no stages left")
+	}
+	state := c.game.State()
+	return c.load(c.stage+1, state.Score, state.Lives)
+}
+
+// Game returns the usecase running the current stage.
+func (c *Campaign) Game() *GameUsecase {
+	return c.game
+}
+
+// Stage returns the 0-based index of the current stage.
+func (c *Campaign) Stage() int {
+	return c.stage
+}
+
+func (c *Campaign) StageCount() int {
+	return len(c.levels)
+}
+
+func (c *Campaign) Level() *level.Level {
+	return c.levels[c.stage]
+}
+
+func (c *Campaign) Upgrades() domain.Upgrades {
+	return c.upgrades
+}
+
+// Tally returns the current stage's clear summary, or nil while it is being played.
+func (c *Campaign) Tally() *domain.StageTally {
+	return c.tally
+}
+
+// Cleared reports whether the current stage has been cleared.
+func (c *Campaign) Cleared() bool {
+	return c.tally != nil
+}
+
+// Completed reports whether the last stage has been cleared.
+func (c *Campaign) Completed() bool {
+	return c.tally != nil && c.stage == len(c.levels)-1
+}
+
+// Lost reports whether the player ran out of lives.
+func (c *Campaign) Lost() bool {
+	state := c.game.State()
+	return state.GameOver && !state.Cleared
+}
@@ -0,0 +1,179 @@
+package application
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"block-game/pkg/config"
+	"block-game/pkg/domain"
+	"block-game/pkg/level"
+)
+
+func testCampaignLevels(t *testing.T, n int) []*level.Level {
+	t.Helper()
+	levels := make([]*level.Level, n)
+	for i := range levels {
+		data := fmt.Sprintf(`{"name": "stage %d", "grid": {"x": 10, "y": 10, "blockW": 70, "blockH": 30, "spacing": 5, "rows": ["NN"]}}`, i+1)
+		lvl, err := level.Parse("test.json", []byte(data))
+		if err != nil {
+			t.Fatalf("parse: %v", err)
+		}
+		levels[i] = lvl
+	}
+	return levels
+}
+
+func testLayoutFunc(lvl *level.Level) (domain.LayoutConfig, error) {
+	cfg, _, err := config.LayoutForLevel(lvl, "NORMAL")
+	return cfg, err
+}
+
+// clearStage destroys every block and runs one step so the stage registers as cleared.
+func clearStage(t *testing.T, c *Campaign, points int) {
+	t.Helper()
+	state := c.Game().State()
+	for i := range state.Blocks {
+		state.DestroyBlock(i, c.Game().Layout())
+	}
+	state.Score += points
+	if err := c.Update(c.Game().Layout().StepDuration()); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if !c.Cleared() {
+		t.Fatalf("expected stage %d to be cleared", c.Stage())
+	}
+}
+
+func TestNewCampaignValidatesArguments(t *testing.T) {
+	if _, err := NewCampaign(nil, testLayoutFunc, config.DefaultStageRules(), nil, &fakeInput{}); err != ErrNoLevels {
+		t.Fatalf("expected ErrNoLevels, got %v", err)
+	}
+	if _, err := NewCampaign(testCampaignLevels(t, 1), testLayoutFunc, config.DefaultStageRules(), nil, nil); err != ErrNilInputPort {
+		t.Fatalf("expected ErrNilInputPort, got %v", err)
+	}
+}
+
+func TestCampaignCarriesScoreLivesAndUpgrades(t *testing.T) {
+	rules := config.DefaultStageRules()
+	c, err := NewCampaign(testCampaignLevels(t, 3), testLayoutFunc, rules, domain.NewRandomSource(nil), &fakeInput{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	firstSpeed := c.Game().Layout().BallSpeed
+	firstWidth := c.Game().Layout().PaddleWidth
+
+	clearStage(t, c, 7)
+	tally := c.Tally()
+	if tally.BlockScore != 7 || !tally.Perfect || !tally.Upgraded {
+		t.Fatalf("unexpected tally: %+v", tally)
+	}
+	score := c.Game().State().Score
+	if score != 7+tally.Bonus() {
+		t.Fatalf("bonus should be added to the score, got %d", score)
+	}
+	if err := c.NextStage(); err != nil {
+		t.Fatalf("next stage: %v", err)
+	}
+
+	if c.Stage() != 1 || c.Level().Name != "stage 2" {
+		t.Fatalf("expected second stage, got %d %q", c.Stage(), c.Level().Name)
+	}
+	state := c.Game().State()
+	if state.Score != score || state.Lives != config.Lives {
+		t.Fatalf("score and lives should carry over, got %d %d", state.Score, state.Lives)
+	}
+	layout := c.Game().Layout()
+	if layout.BallSpeed <= firstSpeed {
+		t.Fatalf("ball speed should ramp up: %v -> %v", firstSpeed, layout.BallSpeed)
+	}
+	wantWidth := firstWidth * (1 - rules.PaddleWidthStep) * (1 + rules.PerfectPaddleBonus)
+	if layout.PaddleWidth != wantWidth || state.Paddle.Width != wantWidth {
+		t.Fatalf("paddle width should carry the upgrade: want %v got %v", wantWidth, layout.PaddleWidth)
+	}
+}
+
+func TestCampaignLostLifeForfeitsPerfectBonus(t *testing.T) {
+	c, err := NewCampaign(testCampaignLevels(t, 2), testLayoutFunc, config.DefaultStageRules(), domain.NewRandomSource(nil), &fakeInput{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	state := c.Game().State()
+	state.Balls = state.Balls[:0] // the ball fell
+	if err := c.Update(c.Game().Layout().StepDuration()); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	clearStage(t, c, 0)
+	if c.Tally().Perfect || c.Game().State().Lives != config.Lives-1 {
+		t.Fatalf("expected an imperfect clear with one life lost: %+v lives %d", c.Tally(), c.Game().State().Lives)
+	}
+	if err := c.NextStage(); err != nil {
+		t.Fatalf("next stage: %v", err)
+	}
+	if c.Game().State().Lives != config.Lives-1 {
+		t.Fatalf("lost life should carry over, got %d", c.Game().State().Lives)
+	}
+}
+
+func TestCampaignLostLifeCountsDespiteExtraLife(t *testing.T) {
+	c, err := NewCampaign(testCampaignLevels(t, 2), testLayoutFunc, config.DefaultStageRules(), domain.NewRandomSource(nil), &fakeInput{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// the ball falls in the step that catches an extra life, so the lives do not change
+	state := c.Game().State()
+	p := state.Paddle
+	state.Balls = state.Balls[:0]
+	state.Items = append(state.Items, domain.Item{X: p.X, Y: p.Y, Width: 10, Height: 10, Active: true, Type: domain.ItemTypeExtraLife})
+	if err := c.Update(c.Game().Layout().StepDuration()); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if state.Lives != config.Lives {
+		t.Fatalf("expected the extra life to make up for the loss, got %d lives", state.Lives)
+	}
+	clearStage(t, c, 0)
+	if c.Tally().Perfect {
+		t.Fatalf("expected the lost life to forfeit the perfect bonus: %+v", c.Tally())
+	}
+}
+
+func TestCampaignCompletesAfterLastStage(t *testing.T) {
+	c, err := NewCampaign(testCampaignLevels(t, 2), testLayoutFunc, config.DefaultStageRules(), domain.NewRandomSource(nil), &fakeInput{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := c.NextStage(); err == nil {
+		t.Fatalf("NextStage should fail before the stage is cleared")
+	}
+	clearStage(t, c, 0)
+	if c.Completed() {
+		t.Fatalf("campaign should not be completed after the first of two stages")
+	}
+	if err := c.NextStage(); err != nil {
+		t.Fatalf("next stage: %v", err)
+	}
+	clearStage(t, c, 0)
+	if !c.Completed() || c.Lost() {
+		t.Fatalf("expected completed campaign")
+	}
+	if err := c.NextStage(); err == nil {
+		t.Fatalf("NextStage should fail after the last stage")
+	}
+}
+
+func TestCampaignLost(t *testing.T) {
+	c, err := NewCampaign(testCampaignLevels(t, 2), testLayoutFunc, config.DefaultStageRules(), domain.NewRandomSource(nil), &fakeInput{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i := 0; i < config.Lives; i++ {
+		state := c.Game().State()
+		state.Balls = state.Balls[:0]
+		if err := c.Update(time.Second / config.TickRate); err != nil {
+			t.Fatalf("update: %v", err)
+		}
+	}
+	if !c.Lost() || c.Cleared() {
+		t.Fatalf("expected the campaign to be lost")
+	}
+}
@@ -0,0 +1,49 @@
+package application
+
+import "block-game/pkg/domain"
+
+// EventSubscriber reacts to the domain events of each step, e.g. to play sounds, spawn
+// particles or keep statistics, without the domain knowing about it.
+type EventSubscriber interface {
+	HandleEvent(tick int, e domain.Event)
+}
+
+// EventFunc adapts a function to an EventSubscriber.
+type EventFunc func(tick int, e domain.Event)
+
+func (f EventFunc) HandleEvent(tick int, e domain.Event) {
+	f(tick, e)
+}
+
+// Subscribe delivers the events of every later step to s, in the order they occurred.
+func (g *GameUsecase) Subscribe(s EventSubscriber) {
+	g.subscribers = append(g.subscribers, s)
+}
+
+// publish hands the events of the latest step to the subscribers.
+func (g *GameUsecase) publish() {
+	g.publishFrom(0)
+}
+
+// publishFrom hands the latest step's events from index i on to the subscribers.
+func (g *GameUsecase) publishFrom(i int) {
+	for _, e := range g.state.Events[i:] {
+		for _, s := range g.subscribers {
+			s.HandleEvent(g.state.Tick, e)
+		}
+	}
+}
+
+// awardBonus adds points outside a step, e.g. a stage-clear bonus, and publishes the
+// resulting ScoreChanged as part of the latest step.
+func (g *GameUsecase) awardBonus(points int) {
+	n := len(g.state.Events)
+	g.state.AddScore(points)
+	g.publishFrom(n)
+}
+
+// Subscribe delivers the events of the current and every later stage to s.
+func (c *Campaign) Subscribe(s EventSubscriber) {
+	c.subscribers = append(c.subscribers, s)
+	c.game.Subscribe(s)
+}
@@ -0,0 +1,131 @@
+package application
+
+import (
+	"reflect"
+	"testing"
+
+	"block-game/pkg/config"
+	"block-game/pkg/domain"
+)
+
+type loggedEvent struct {
+	tick  int
+	event domain.Event
+}
+
+// eventLog is a subscriber that keeps everything it is sent.
+type eventLog []loggedEvent
+
+func (l *eventLog) HandleEvent(tick int, e domain.Event) {
+	*l = append(*l, loggedEvent{tick, e})
+}
+
+func seededEventRun(t *testing.T) (*GameUsecase, []loggedEvent, eventLog) {
+	t.Helper()
+	cfg, _, err := config.LayoutWithDifficulty("HARD")
+	if err != nil {
+		t.Fatalf("layout: %v", err)
+	}
+	cfg.Drops.Chance = 0.8
+	seed := int64(11)
+	cfg.Seed = &seed
+
+	game, err := NewGameUsecase(cfg, nil, &scriptedInput{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var log eventLog
+	game.Subscribe(&log)
+
+	var perStep []loggedEvent
+	for !game.State().GameOver {
+		game.Step()
+		for _, e := range game.State().Events {
+			perStep = append(perStep, loggedEvent{game.State().Tick, e})
+		}
+	}
+	return game, perStep, log
+}
+
+func TestSubscribersReceiveEveryStepsEventsInOrder(t *testing.T) {
+	game, perStep, log := seededEventRun(t)
+	if len(log) == 0 {
+		t.Fatalf("expected events from the run")
+	}
+	if !reflect.DeepEqual([]loggedEvent(log), perStep) {
+		t.Fatalf("subscriber saw a different sequence than the steps produced")
+	}
+
+	// The events account for every change the run made.
+	var destroyed, spawned, lost, score int
+	for _, l := range log {
+		switch e := l.event.(type) {
+		case domain.BlockDestroyed:
+			destroyed++
+		case domain.ItemSpawned:
+			spawned++
+		case domain.BallLost:
+			lost++
+		case domain.ScoreChanged:
+			score = e.Score
+		}
+	}
+	stats := game.State().Stats
+	if destroyed != stats.BlocksDestroyed || spawned != stats.ItemsDropped || lost != stats.BallsLost {
+		t.Fatalf("events (%d destroyed, %d spawned, %d lost) disagree with stats %+v", destroyed, spawned, lost, stats)
+	}
+	if score != game.State().Score {
+		t.Fatalf("last ScoreChanged says %d, state has %d", score, game.State().Score)
+	}
+	last := log[len(log)-1]
+	if _, ok := last.event.(domain.GameOver); !ok || last.tick != game.State().Tick {
+		t.Fatalf("expected the run to end with GameOver at tick %d, got %#v", game.State().Tick, last)
+	}
+}
+
+func TestEventSequenceIsDeterministic(t *testing.T) {
+	_, _, first := seededEventRun(t)
+	_, _, second := seededEventRun(t)
+	if !reflect.DeepEqual(first, second) {
+		t.Fatalf("two runs with the same seed produced different events")
+	}
+}
+
+func TestCampaignSubscribersFollowLaterStages(t *testing.T) {
+	c, err := NewCampaign(testCampaignLevels(t, 2), testLayoutFunc, config.DefaultStageRules(), nil, &fakeInput{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var log eventLog
+	c.Subscribe(&log)
+	clearStage(t, c, 0)
+	bonus, score := c.Tally().Bonus(), c.Game().State().Score
+	if err := c.NextStage(); err != nil {
+		t.Fatalf("next stage: %v", err)
+	}
+
+	state := c.Game().State()
+	ball := &state.Balls[0]
+	ball.Attached = false
+	ball.X, ball.Y, ball.VX, ball.VY = 300, c.Game().Layout().ScreenH-ball.Radius, 0, 300
+	c.Game().Step()
+
+	want := eventLog{
+		{1, domain.GameOver{Cleared: true}}, // stage 1, cleared by clearStage
+		{1, domain.ScoreChanged{Delta: bonus, Score: score}},
+		{1, domain.BallLost{X: 300}},
+		{1, domain.LifeLost{Lives: state.Lives}},
+	}
+	if !reflect.DeepEqual(log, want) {
+		t.Fatalf("events:\n got %#v\nwant %#v", log, want)
+	}
+}
+
+func TestEventFuncAdaptsFunctions(t *testing.T) {
+	var got []domain.Event
+	var s EventSubscriber = EventFunc(func(_ int, e domain.Event) { got = append(got, e) })
+	s.HandleEvent(3, domain.LifeLost{Lives: 2})
+	if len(got) != 1 || got[0] != (domain.LifeLost{Lives: 2}) {
+		t.Fatalf("unexpected events %#v", got)
+	}
+}
@@ -2,8 +2,10 @@ package application
 
 import (
 	"errors"
+	"time"
 
 	"block-game/pkg/domain"
+	"block-game/pkg/level"
 )
 
 var ErrNilInputPort = errors.New("input port is nil")
@@ -13,10 +15,16 @@ type InputPort interface {
 }
 
 type GameUsecase struct {
-	state  *domain.GameState
-	layout domain.LayoutConfig
-	input  InputPort
-	rnd    domain.RandomSource
+	state   *domain.GameState
+	prev    domain.GameState // state before the latest step, for render interpolation
+	layout  domain.LayoutConfig
+	input   InputPort
+	rnd     domain.RandomSource // root source; streams are split off it
+	items   domain.RandomSource // stream the simulation draws item drops from
+	clock   *domain.FixedStep
+	history *history // states before recent steps, when rewinding is enabled
+
+	subscribers []EventSubscriber
 }
 
 func NewGameUsecase(layout domain.LayoutConfig, rnd domain.RandomSource, input InputPort) (*GameUsecase, error) {
@@ -24,32 +32,95 @@ func NewGameUsecase(layout domain.LayoutConfig, rnd domain.RandomSource, input I
 		return nil, ErrNilInputPort
 	}
 
-	blocks, err := domain.GenerateBlocks(layout, rnd)
+	if rnd == nil {
+		rnd = domain.NewRandomSource(layout.Seed)
+	}
+	blockRnd := domain.SplitStream(rnd, domain.StreamBlocks)
+	blocks, err := domain.GenerateBlocks(layout, blockRnd)
 	if err != nil {
-		blocks = domain.GenerateGridFallback(layout)
+		blocks = domain.GenerateGridFallback(layout, blockRnd)
+	}
+	return newGameUsecase(layout, blocks, rnd, input), nil
+}
+
+// NewGameUsecaseFromLevel starts a game on a loaded level instead of generated blocks.
+// layout should already carry the level's overrides (see config.LayoutForLevel);
+// the level is validated against it.
+func NewGameUsecaseFromLevel(lvl *level.Level, layout domain.LayoutConfig, rnd domain.RandomSource, input InputPort) (*GameUsecase, error) {
+	if input == nil {
+		return nil, ErrNilInputPort
 	}
+	if err := lvl.Validate(layout); err != nil {
+		return nil, err
+	}
+	return newGameUsecase(layout, lvl.BuildBlocks(layout), rnd, input), nil
+}
+
+func newGameUsecase(layout domain.LayoutConfig, blocks []domain.Block, rnd domain.RandomSource, input InputPort) *GameUsecase {
 	state := domain.NewGameState(layout, blocks)
 	if rnd == nil {
 		rnd = domain.NewRandomSource(layout.Seed)
 	}
 
-	return &GameUsecase{
+	g := &GameUsecase{
 		state:  state,
 		layout: layout,
 		input:  input,
 		rnd:    rnd,
-	}, nil
+		items:  domain.SplitStream(rnd, domain.StreamItems),
+		clock:  domain.NewFixedStep(layout.StepDuration()),
+	}
+	g.capturePrevious()
+	return g
 }
 
-func (g *GameUsecase) Update() error {
-	domain.Advance(g.state, g.input.Read(), g.layout, g.rnd)
+// Update advances the simulation by elapsed wall-clock time in fixed steps.
+// Input is sampled once per step so runs do not depend on the caller's frame rate.
+func (g *GameUsecase) Update(elapsed time.Duration) error {
+	for steps := g.clock.Add(elapsed); steps > 0; steps-- {
+		g.Step()
+	}
 	return nil
 }
 
+// Step advances the simulation by exactly one fixed step.
+// Once the game is over no input is read, so recorded runs stay aligned tick for tick.
+func (g *GameUsecase) Step() {
+	if g.state.GameOver {
+		return
+	}
+	g.capturePrevious()
+	g.record()
+	domain.Advance(g.state, g.input.Read(), g.layout, g.items)
+	g.publish()
+}
+
 func (g *GameUsecase) State() *domain.GameState {
 	return g.state
 }
 
+// Previous returns the state as it was before the latest step.
+// Only the moving entities (balls, paddle, items, projectiles) are meant to be read from it.
+func (g *GameUsecase) Previous() *domain.GameState {
+	return &g.prev
+}
+
+// Alpha returns how far the accumulated time is between Previous and State, in [0, 1).
+func (g *GameUsecase) Alpha() float64 {
+	return g.clock.Alpha()
+}
+
 func (g *GameUsecase) Layout() domain.LayoutConfig {
 	return g.layout
 }
+
+// capturePrevious copies the moving entities, reusing the previous buffers.
+func (g *GameUsecase) capturePrevious() {
+	balls := append(g.prev.Balls[:0], g.state.Balls...)
+	items := append(g.prev.Items[:0], g.state.Items...)
+	projectiles := append(g.prev.Projectiles[:0], g.state.Projectiles...)
+	g.prev = *g.state
+	g.prev.Balls = balls
+	g.prev.Items = items
+	g.prev.Projectiles = projectiles
+}
@@ -5,6 +5,7 @@ import (
 
 	"block-game/pkg/config"
 	"block-game/pkg/domain"
+	"block-game/pkg/level"
 )
 
 type fakeInput struct {
@@ -44,7 +45,7 @@ func TestUpdateAppliesInput(t *testing.T) {
 		t.Fatalf("unexpected error: %v", err)
 	}
 	initialX := usecase.State().Paddle.X
-	if err := usecase.Update(); err != nil {
+	if err := usecase.Update(cfg.StepDuration()); err != nil {
 		t.Fatalf("update error: %v", err)
 	}
 	if usecase.State().Paddle.X <= initialX {
@@ -54,3 +55,81 @@ func TestUpdateAppliesInput(t *testing.T) {
 		t.Fatalf("expected at least one ball after update")
 	}
 }
+
+func TestUpdateRunsFixedStepsForElapsedTime(t *testing.T) {
+	cfg := config.DefaultLayoutConfig()
+	usecase, err := NewGameUsecase(cfg, domain.NewRandomSource(cfg.Seed), &fakeInput{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := usecase.Update(cfg.StepDuration() / 2); err != nil {
+		t.Fatalf("update error: %v", err)
+	}
+	if usecase.State().Tick != 0 {
+		t.Fatalf("expected no step for half a step of time, got %d", usecase.State().Tick)
+	}
+	if usecase.Alpha() < 0.49 || usecase.Alpha() > 0.51 {
+		t.Fatalf("expected alpha ~0.5, got %f", usecase.Alpha())
+	}
+
+	if err := usecase.Update(3 * cfg.StepDuration() / 2); err != nil {
+		t.Fatalf("update error: %v", err)
+	}
+	if usecase.State().Tick != 2 {
+		t.Fatalf("expected 2 steps, got %d", usecase.State().Tick)
+	}
+}
+
+func TestPreviousHoldsStateBeforeLatestStep(t *testing.T) {
+	cfg := config.DefaultLayoutConfig()
+	fi := &fakeInput{state: domain.InputState{Launch: true}}
+	usecase, err := NewGameUsecase(cfg, domain.NewRandomSource(cfg.Seed), fi)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	before := usecase.State().Balls[0]
+	usecase.Step()
+	prev := usecase.Previous()
+	if prev.Balls[0] != before {
+		t.Fatalf("expected previous ball %+v, got %+v", before, prev.Balls[0])
+	}
+	if usecase.State().Balls[0] == before {
+		t.Fatalf("expected current ball to have moved")
+	}
+}
+
+func TestNewGameUsecaseFromLevel(t *testing.T) {
+	lvl, err := level.Parse("test.json", []byte(`{
+  "ball": {"speed": 200},
+  "grid": {"x": 10, "y": 10, "blockW": 70, "blockH": 30, "spacing": 5, "rows": ["NH", "EX"]}
+}`))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	cfg := lvl.ApplyTo(config.DefaultLayoutConfig())
+
+	usecase, err := NewGameUsecaseFromLevel(lvl, cfg, domain.NewRandomSource(cfg.Seed), &fakeInput{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	blocks := usecase.State().Blocks
+	if len(blocks) != 4 || blocks[1].Type != domain.BlockTypeHard || blocks[3].Type != domain.BlockTypeIndestructible {
+		t.Fatalf("expected the level's blocks, got %+v", blocks)
+	}
+	if usecase.Layout().BallSpeed != 200 {
+		t.Fatalf("expected level ball speed, got %v", usecase.Layout().BallSpeed)
+	}
+}
+
+func TestNewGameUsecaseFromLevelRejectsInvalidLevel(t *testing.T) {
+	lvl, err := level.Parse("test.json", []byte(`{"blocks": [{"x": 0, "y": 500, "w": 70, "h": 30}]}`))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	cfg := config.DefaultLayoutConfig()
+	if _, err := NewGameUsecaseFromLevel(lvl, cfg, domain.NewRandomSource(cfg.Seed), &fakeInput{}); err == nil {
+		t.Fatalf("expected a validation error for a block below the paddle gap")
+	}
+}
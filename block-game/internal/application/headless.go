@@ -0,0 +1,148 @@
+package application
+
+import (
+	"errors"
+
+	"block-game/pkg/domain"
+	"block-game/pkg/level"
+)
+
+// Outcome is how a headless run ended.
+type Outcome string
+
+const (
+	OutcomeCleared    Outcome = "cleared"
+	OutcomeLost       Outcome = "lost"
+	OutcomeUnfinished Outcome = "unfinished" // stopped at the tick limit or when the input ran out
+)
+
+// HeadlessRun configures one game played without a window.
+type HeadlessRun struct {
+	Layout   domain.LayoutConfig // should be seeded so the run is reproducible
+	Level    *level.Level        // nil generates blocks from Layout
+	Input    InputPort           // StateObserver inputs are given the game state
+	MaxTicks int                 // steps to simulate before giving up; must be positive
+}
+
+// RunResult summarizes a finished headless run.
+type RunResult struct {
+	Seed            int64             `json:"seed"`
+	Difficulty      domain.Difficulty `json:"difficulty"`
+	Level           string            `json:"level,omitempty"`
+	Outcome         Outcome           `json:"outcome"`
+	Score           int               `json:"score"`
+	Ticks           int               `json:"ticks"`
+	Seconds         float64           `json:"seconds"`
+	LivesLeft       int               `json:"livesLeft"`
+	BallsLost       int               `json:"ballsLost"`
+	ItemsDropped    int               `json:"itemsDropped"`
+	ItemsCollected  int               `json:"itemsCollected"`
+	BlocksDestroyed int               `json:"blocksDestroyed"`
+}
+
+// finiteInput is implemented by inputs that can run out, such as replays and scripts.
+type finiteInput interface {
+	Done() bool
+}
+
+// RunHeadless plays one game to its end, the tick limit or the end of a finite input.
+func RunHeadless(run HeadlessRun) (RunResult, error) {
+	if run.Input == nil {
+		return RunResult{}, ErrNilInputPort
+	}
+	if run.MaxTicks <= 0 {
+		return RunResult{}, errors.New("max ticks must be positive")
+	}
+	layout := run.Layout
+	rnd := domain.NewRandomSource(layout.Seed)
+
+	var usecase *GameUsecase
+	var err error
+	if run.Level != nil {
+		usecase, err = NewGameUsecaseFromLevel(run.Level, layout, rnd, run.Input)
+	} else {
+		usecase, err = NewGameUsecase(layout, rnd, run.Input)
+	}
+	if err != nil {
+		return RunResult{}, err
+	}
+	if observer, ok := run.Input.(StateObserver); ok {
+		observer.Observe(usecase.State(), usecase.Layout())
+	}
+	finite, _ := run.Input.(finiteInput)
+
+	state := usecase.State()
+	for !state.GameOver && state.Tick < run.MaxTicks {
+		if finite != nil && finite.Done() {
+			break
+		}
+		usecase.Step()
+	}
+	return resultOf(usecase, run.Level), nil
+}
+
+func resultOf(usecase *GameUsecase, lvl *level.Level) RunResult {
+	state := usecase.State()
+	layout := usecase.Layout()
+	r := RunResult{
+		Difficulty:      layout.Difficulty,
+		Outcome:         OutcomeUnfinished,
+		Score:           state.Score,
+		Ticks:           state.Tick,
+		Seconds:         float64(state.Tick) * layout.StepSeconds(),
+		LivesLeft:       state.Lives,
+		BallsLost:       state.Stats.BallsLost,
+		ItemsDropped:    state.Stats.ItemsDropped,
+		ItemsCollected:  state.Stats.ItemsCollected,
+		BlocksDestroyed: state.Stats.BlocksDestroyed,
+	}
+	if layout.Seed != nil {
+		r.Seed = *layout.Seed
+	}
+	if lvl != nil {
+		r.Level = lvl.Name
+	}
+	switch {
+	case state.Cleared:
+		r.Outcome = OutcomeCleared
+	case state.GameOver:
+		r.Outcome = OutcomeLost
+	}
+	return r
+}
+
+// RunSummary aggregates the results of several runs.
+type RunSummary struct {
+	Runs       int     `json:"runs"`
+	Cleared    int     `json:"cleared"`
+	Lost       int     `json:"lost"`
+	Unfinished int     `json:"unfinished"`
+	MeanScore  float64 `json:"meanScore"`
+	MeanTicks  float64 `json:"meanTicks"`
+	BestScore  int     `json:"bestScore"`
+}
+
+func Summarize(results []RunResult) RunSummary {
+	s := RunSummary{Runs: len(results)}
+	if len(results) == 0 {
+		return s
+	}
+	for i, r := range results {
+		switch r.Outcome {
+		case OutcomeCleared:
+			s.Cleared++
+		case OutcomeLost:
+			s.Lost++
+		default:
+			s.Unfinished++
+		}
+		s.MeanScore += float64(r.Score)
+		s.MeanTicks += float64(r.Ticks)
+		if i == 0 || r.Score > s.BestScore {
+			s.BestScore = r.Score
+		}
+	}
+	s.MeanScore /= float64(len(results))
+	s.MeanTicks /= float64(len(results))
+	return s
+}
@@ -0,0 +1,96 @@
+package application
+
+import (
+	"strings"
+	"testing"
+
+	"block-game/pkg/config"
+	"block-game/pkg/domain"
+	"block-game/pkg/level"
+)
+
+func seededLayout(seed int64) domain.LayoutConfig {
+	cfg := config.DefaultLayoutConfig()
+	cfg.Seed = &seed
+	return cfg
+}
+
+func TestRunHeadlessWithBotIsDeterministic(t *testing.T) {
+	run := func() RunResult {
+		result, err := RunHeadless(HeadlessRun{
+			Layout:   seededLayout(4),
+			Input:    NewFollowBot(),
+			MaxTicks: 60 * 60 * 10,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return result
+	}
+	first, second := run(), run()
+	if first != second {
+		t.Fatalf("same seed should give the same result:\n%+v\n%+v", first, second)
+	}
+	if first.Seed != 4 || first.Score == 0 || first.BlocksDestroyed == 0 || first.Outcome == OutcomeUnfinished {
+		t.Fatalf("bot should play a full game: %+v", first)
+	}
+}
+
+func TestRunHeadlessStopsAtTickLimit(t *testing.T) {
+	result, err := RunHeadless(HeadlessRun{
+		Layout:   seededLayout(1),
+		Input:    &fakeInput{},
+		MaxTicks: 120,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Outcome != OutcomeUnfinished || result.Ticks != 120 || result.Seconds != 2 {
+		t.Fatalf("expected an unfinished two second run, got %+v", result)
+	}
+}
+
+func TestRunHeadlessStopsWhenScriptEnds(t *testing.T) {
+	script, err := ParseScript(strings.NewReader("launch\nwait 9\n"))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	result, err := RunHeadless(HeadlessRun{Layout: seededLayout(1), Input: script, MaxTicks: 1000})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Ticks != 10 || result.Outcome != OutcomeUnfinished {
+		t.Fatalf("expected the run to stop with the script, got %+v", result)
+	}
+}
+
+func TestRunHeadlessOnLevel(t *testing.T) {
+	lvl, err := level.Parse("tiny.json", []byte(`{"name": "tiny", "blocks": [{"x": 380, "y": 100, "w": 40, "h": 20}]}`))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	cfg, _, err := config.LayoutForLevel(lvl, "NORMAL")
+	if err != nil {
+		t.Fatalf("layout: %v", err)
+	}
+	seed := int64(3)
+	cfg.Seed = &seed
+	result, err := RunHeadless(HeadlessRun{Layout: cfg, Level: lvl, Input: NewFollowBot(), MaxTicks: 60 * 60 * 10})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Level != "tiny" || result.Outcome != OutcomeCleared || result.Score != 1 {
+		t.Fatalf("expected the bot to clear the one-block level, got %+v", result)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	s := Summarize([]RunResult{
+		{Outcome: OutcomeCleared, Score: 10, Ticks: 100},
+		{Outcome: OutcomeLost, Score: 4, Ticks: 50},
+		{Outcome: OutcomeUnfinished, Score: 1, Ticks: 300},
+	})
+	if s.Runs != 3 || s.Cleared != 1 || s.Lost != 1 || s.Unfinished != 1 || s.MeanScore != 5 || s.BestScore != 10 || s.MeanTicks != 150 {
+		t.Fatalf("unexpected summary: %+v", s)
+	}
+}
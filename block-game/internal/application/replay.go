@@ -0,0 +1,122 @@
+package application
+
+import (
+	"errors"
+	"time"
+
+	"block-game/pkg/domain"
+	"block-game/pkg/level"
+	"block-game/pkg/replay"
+)
+
+// Recorder is an InputPort that records every input it passes on, one per step.
+type Recorder struct {
+	input  InputPort
+	inputs []domain.InputState
+}
+
+func NewRecorder(input InputPort) *Recorder {
+	return &Recorder{input: input}
+}
+
+func (r *Recorder) Read() domain.InputState {
+	in := r.input.Read()
+	r.inputs = append(r.inputs, in)
+	return in
+}
+
+// Replay packages the recorded inputs with the layout the run started from.
+// layout.Seed must be set (see SeedLayout) or the run cannot be reproduced.
+func (r *Recorder) Replay(mode replay.Mode, layout domain.LayoutConfig) (*replay.Replay, error) {
+	if layout.Seed == nil {
+		return nil, errors.New("replay needs a seeded layout")
+	}
+	return &replay.Replay{
+		Version:    replay.Version,
+		Mode:       mode,
+		Seed:       *layout.Seed,
+		Difficulty: layout.Difficulty,
+		Layout:     layout,
+		Inputs:     append([]domain.InputState(nil), r.inputs...),
+	}, nil
+}
+
+// ReplayInput is an InputPort that plays recorded inputs back, one per step.
+// After the last recorded step it returns an empty input.
+type ReplayInput struct {
+	inputs []domain.InputState
+	pos    int
+}
+
+func NewReplayInput(inputs []domain.InputState) *ReplayInput {
+	return &ReplayInput{inputs: inputs}
+}
+
+func (p *ReplayInput) Read() domain.InputState {
+	if p.pos >= len(p.inputs) {
+		return domain.InputState{}
+	}
+	in := p.inputs[p.pos]
+	p.pos++
+	return in
+}
+
+// Done reports whether every recorded input has been played.
+func (p *ReplayInput) Done() bool {
+	return p.pos >= len(p.inputs)
+}
+
+// SeedLayout returns layout with a fixed Seed, drawing one from the clock if it has none,
+// so that the run can be recorded and replayed.
+func SeedLayout(layout domain.LayoutConfig) domain.LayoutConfig {
+	if layout.Seed == nil {
+		seed := time.Now().UnixNano()
+		layout.Seed = &seed
+	}
+	return layout
+}
+
+// NewRecordedGame starts a generated stage from a seeded layout and records its input.
+func NewRecordedGame(layout domain.LayoutConfig, input InputPort) (*GameUsecase, *Recorder, error) {
+	if input == nil {
+		return nil, nil, ErrNilInputPort
+	}
+	layout = SeedLayout(layout)
+	recorder := NewRecorder(input)
+	usecase, err := NewGameUsecase(layout, domain.NewRandomSource(layout.Seed), recorder)
+	if err != nil {
+		return nil, nil, err
+	}
+	return usecase, recorder, nil
+}
+
+// NewReplayGame rebuilds a recorded generated stage and feeds its inputs back.
+func NewReplayGame(rep *replay.Replay) (*GameUsecase, *ReplayInput, error) {
+	if rep.Mode != replay.ModeStage {
+		return nil, nil, errors.New("replay is not a single stage run")
+	}
+	layout := rep.Layout
+	seed := rep.Seed
+	layout.Seed = &seed
+	player := NewReplayInput(rep.Inputs)
+	usecase, err := NewGameUsecase(layout, domain.NewRandomSource(layout.Seed), player)
+	if err != nil {
+		return nil, nil, err
+	}
+	return usecase, player, nil
+}
+
+// NewReplayCampaign rebuilds a recorded campaign run over the same levels and feeds its inputs back.
+// The caller must advance stages on clear just as the recorded run did.
+func NewReplayCampaign(rep *replay.Replay, levels []*level.Level, layout LayoutFunc, rules domain.StageRules) (*Campaign, *ReplayInput, error) {
+	if rep.Mode != replay.ModeCampaign {
+		return nil, nil, errors.New("replay is not a campaign run")
+	}
+	seed := rep.Seed
+	player := NewReplayInput(rep.Inputs)
+	campaign, err := NewCampaign(levels, layout, rules, domain.NewRandomSource(&seed), player)
+	if err != nil {
+		return nil, nil, err
+	}
+	return campaign, player, nil
+}
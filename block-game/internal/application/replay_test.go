@@ -0,0 +1,162 @@
+package application
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"block-game/pkg/config"
+	"block-game/pkg/domain"
+	"block-game/pkg/level"
+	"block-game/pkg/replay"
+)
+
+// scriptedInput launches right away and then sweeps the paddle back and forth.
+type scriptedInput struct {
+	tick int
+}
+
+func (s *scriptedInput) Read() domain.InputState {
+	s.tick++
+	phase := (s.tick / 45) % 3
+	return domain.InputState{
+		Launch:    s.tick%120 == 1,
+		MoveLeft:  phase == 0,
+		MoveRight: phase == 2,
+		Fire:      s.tick%2 == 0,
+	}
+}
+
+// roundTrip encodes and decodes a replay the way it would go through a file.
+func roundTrip(t *testing.T, rep *replay.Replay) *replay.Replay {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := replay.Encode(&buf, rep); err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	decoded, err := replay.Decode(&buf)
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	return decoded
+}
+
+func assertSameState(t *testing.T, got, want *domain.GameState) {
+	t.Helper()
+	if got.Score != want.Score || got.Lives != want.Lives || got.Tick != want.Tick ||
+		got.GameOver != want.GameOver || got.Cleared != want.Cleared {
+		t.Fatalf("summary mismatch: got score %d lives %d tick %d, want score %d lives %d tick %d",
+			got.Score, got.Lives, got.Tick, want.Score, want.Lives, want.Tick)
+	}
+	if !reflect.DeepEqual(got.Blocks, want.Blocks) {
+		t.Fatalf("blocks differ after playback")
+	}
+	if !reflect.DeepEqual(got.Balls, want.Balls) || !reflect.DeepEqual(got.Items, want.Items) ||
+		!reflect.DeepEqual(got.Projectiles, want.Projectiles) || got.FireCooldown != want.FireCooldown {
+		t.Fatalf("balls, items or projectiles differ after playback:\n got %+v\nwant %+v", got.Balls, want.Balls)
+	}
+	if got.Paddle != want.Paddle || got.Barrier != want.Barrier || !reflect.DeepEqual(got.Effects, want.Effects) {
+		t.Fatalf("paddle differs after playback: got %+v want %+v", got.Paddle, want.Paddle)
+	}
+}
+
+func TestReplayReproducesStage(t *testing.T) {
+	cfg, _, err := config.LayoutWithDifficulty("HARD")
+	if err != nil {
+		t.Fatalf("layout: %v", err)
+	}
+	cfg.Drops.Chance = 0.5
+
+	recorded, recorder, err := NewRecordedGame(cfg, &scriptedInput{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i := 0; i < 3600; i++ {
+		recorded.Step()
+	}
+	if recorded.State().Score == 0 {
+		t.Fatalf("scripted run should score to make the test meaningful")
+	}
+
+	rep, err := recorder.Replay(replay.ModeStage, recorded.Layout())
+	if err != nil {
+		t.Fatalf("replay: %v", err)
+	}
+	played, player, err := NewReplayGame(roundTrip(t, rep))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for !player.Done() && !played.State().GameOver {
+		played.Step()
+	}
+	assertSameState(t, played.State(), recorded.State())
+}
+
+func TestReplayReproducesCampaignAcrossStages(t *testing.T) {
+	levels, err := level.Campaign(config.DefaultLayoutConfig())
+	if err != nil {
+		t.Fatalf("campaign: %v", err)
+	}
+	rules := config.DefaultStageRules()
+	base := SeedLayout(config.DefaultLayoutConfig())
+
+	recorder := NewRecorder(&scriptedInput{})
+	recorded, err := NewCampaign(levels, testLayoutFunc, rules, domain.NewRandomSource(base.Seed), recorder)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Clear the first stage by hand mid-run so the replay has to cross a stage boundary.
+	for i := 0; i < 600; i++ {
+		recorded.Game().Step()
+	}
+	clearStage(t, recorded, 0)
+	if err := recorded.NextStage(); err != nil {
+		t.Fatalf("next stage: %v", err)
+	}
+	for i := 0; i < 1800; i++ {
+		recorded.Game().Step()
+	}
+
+	rep, err := recorder.Replay(replay.ModeCampaign, base)
+	if err != nil {
+		t.Fatalf("replay: %v", err)
+	}
+	played, player, err := NewReplayCampaign(roundTrip(t, rep), levels, testLayoutFunc, rules)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i := 0; i < 600; i++ {
+		played.Game().Step()
+	}
+	clearStage(t, played, 0)
+	if err := played.NextStage(); err != nil {
+		t.Fatalf("next stage: %v", err)
+	}
+	for !player.Done() && !played.Game().State().GameOver {
+		played.Game().Step()
+	}
+	assertSameState(t, played.Game().State(), recorded.Game().State())
+}
+
+func TestRecorderReplayRequiresSeed(t *testing.T) {
+	recorder := NewRecorder(&fakeInput{})
+	if _, err := recorder.Replay(replay.ModeStage, config.DefaultLayoutConfig()); err == nil {
+		t.Fatalf("expected an error for an unseeded layout")
+	}
+}
+
+func TestStepReadsNoInputAfterGameOver(t *testing.T) {
+	cfg := config.DefaultLayoutConfig()
+	recorded, recorder, err := NewRecordedGame(cfg, &fakeInput{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	recorded.Step()
+	recorded.State().GameOver = true
+	recorded.Step()
+	recorded.Step()
+	rep, _ := recorder.Replay(replay.ModeStage, recorded.Layout())
+	if len(rep.Inputs) != 1 {
+		t.Fatalf("expected one recorded input, got %d", len(rep.Inputs))
+	}
+}
@@ -0,0 +1,90 @@
+package application
+
+import (
+	"errors"
+
+	"block-game/pkg/domain"
+)
+
+// history is a ring buffer of the states before the most recent steps.
+type history struct {
+	states []domain.GameState
+	items  []domain.RandomState // item stream position before each kept step
+	next   int                  // slot the next push writes to
+	count  int
+}
+
+func newHistory(steps int) *history {
+	return &history{
+		states: make([]domain.GameState, steps),
+		items:  make([]domain.RandomState, steps),
+	}
+}
+
+// push keeps a copy of state, reusing the buffers of the slot it overwrites.
+func (h *history) push(state *domain.GameState, items domain.RandomState) {
+	state.CloneInto(&h.states[h.next])
+	h.items[h.next] = items
+
+	h.next = (h.next + 1) % len(h.states)
+	if h.count < len(h.states) {
+		h.count++
+	}
+}
+
+// pop removes the latest kept state and returns a copy that shares nothing with the buffer.
+func (h *history) pop() (domain.GameState, domain.RandomState, bool) {
+	if h.count == 0 {
+		return domain.GameState{}, domain.RandomState{}, false
+	}
+	h.next = (h.next - 1 + len(h.states)) % len(h.states)
+	h.count--
+	return h.states[h.next].Clone(), h.items[h.next], true
+}
+
+// EnableRewind keeps the state before each of the last steps steps so that StepBack can
+// undo them; steps <= 0 turns rewinding off. The item stream must report its state so
+// that steps taken again after a rewind draw the same numbers.
+func (g *GameUsecase) EnableRewind(steps int) error {
+	if steps <= 0 {
+		g.history = nil
+		return nil
+	}
+	if _, ok := g.items.(domain.StatefulRandom); !ok {
+		return errors.New("rewind needs a random source with state")
+	}
+	g.history = newHistory(steps)
+	return nil
+}
+
+// StepBack undoes the latest step, including the item stream's draws. It reports false
+// when no earlier state is kept. The input port is not rewound.
+func (g *GameUsecase) StepBack() bool {
+	if g.history == nil {
+		return false
+	}
+	state, items, ok := g.history.pop()
+	if !ok {
+		return false
+	}
+	*g.state = state
+	g.items = domain.RestoreRandomSource(items)
+	g.capturePrevious()
+	return true
+}
+
+// RewindDepth returns how many steps StepBack can currently undo.
+func (g *GameUsecase) RewindDepth() int {
+	if g.history == nil {
+		return 0
+	}
+	return g.history.count
+}
+
+// record keeps the state before a step when rewinding is enabled.
+func (g *GameUsecase) record() {
+	if g.history == nil {
+		return
+	}
+	g.history.push(g.state, g.items.(domain.StatefulRandom).State())
+}
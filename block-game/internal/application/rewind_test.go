@@ -0,0 +1,128 @@
+package application
+
+import (
+	"reflect"
+	"testing"
+
+	"block-game/pkg/config"
+	"block-game/pkg/domain"
+)
+
+func TestStepBackRestoresEarlierStatesAndReplaysForward(t *testing.T) {
+	cfg, _, err := config.LayoutWithDifficulty("HARD")
+	if err != nil {
+		t.Fatalf("layout: %v", err)
+	}
+	cfg.Drops.Chance = 0.5
+	seed := int64(11)
+	cfg.Seed = &seed
+
+	input := &scriptedInput{}
+	game, err := NewGameUsecase(cfg, domain.NewRandomSource(cfg.Seed), input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := game.EnableRewind(120); err != nil {
+		t.Fatalf("enable rewind: %v", err)
+	}
+	for i := 0; i < 150; i++ {
+		game.Step()
+	}
+	mark := game.State().Clone()
+	markTick := input.tick
+	for i := 0; i < 100; i++ {
+		game.Step()
+	}
+	want := game.State().Clone()
+	if want.GameOver {
+		t.Fatalf("run should still be going so every step is kept")
+	}
+
+	for i := 0; i < 100; i++ {
+		if !game.StepBack() {
+			t.Fatalf("step back %d failed with depth %d", i, game.RewindDepth())
+		}
+	}
+	assertSameState(t, game.State(), &mark)
+
+	// The input port is not rewound; replay the same inputs by hand.
+	input.tick = markTick
+	for i := 0; i < 100; i++ {
+		game.Step()
+	}
+	assertSameState(t, game.State(), &want)
+}
+
+func TestRewindKeepsOnlyTheLatestSteps(t *testing.T) {
+	game, err := NewGameUsecase(config.DefaultLayoutConfig(), nil, &fakeInput{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if game.StepBack() {
+		t.Fatalf("step back should fail while rewinding is off")
+	}
+	if err := game.EnableRewind(10); err != nil {
+		t.Fatalf("enable rewind: %v", err)
+	}
+	for i := 0; i < 25; i++ {
+		game.Step()
+	}
+	if game.RewindDepth() != 10 {
+		t.Fatalf("expected depth 10, got %d", game.RewindDepth())
+	}
+	for i := 0; i < 10; i++ {
+		game.StepBack()
+	}
+	if game.State().Tick != 15 {
+		t.Fatalf("expected to rewind to tick 15, got %d", game.State().Tick)
+	}
+	if game.StepBack() {
+		t.Fatalf("step back past the buffer should fail")
+	}
+}
+
+func TestStepBackRestoresContacts(t *testing.T) {
+	game, err := NewGameUsecase(config.DefaultLayoutConfig(), nil, &fakeInput{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := game.EnableRewind(4); err != nil {
+		t.Fatalf("enable rewind: %v", err)
+	}
+	game.Step()
+	game.State().Contacts = append(game.State().Contacts, domain.Contact{X: 1, Block: -1})
+	game.Step()
+	game.StepBack()
+	if len(game.State().Contacts) != 1 || game.State().Contacts[0].X != 1 {
+		t.Fatalf("expected the contacts of the restored step, got %+v", game.State().Contacts)
+	}
+}
+
+func TestStepBackRestoresEvents(t *testing.T) {
+	game, err := NewGameUsecase(config.DefaultLayoutConfig(), nil, &fakeInput{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := game.EnableRewind(4); err != nil {
+		t.Fatalf("enable rewind: %v", err)
+	}
+	game.Step()
+	game.State().AddScore(7)
+	game.Step()
+	game.State().AddScore(1) // reuses the events buffer of the live state
+	game.StepBack()
+	want := []domain.Event{domain.ScoreChanged{Delta: 7, Score: 7}}
+	if !reflect.DeepEqual(game.State().Events, want) {
+		t.Fatalf("expected the events of the restored step, got %+v", game.State().Events)
+	}
+}
+
+func TestEnableRewindNeedsStatefulRandom(t *testing.T) {
+	game, err := NewGameUsecase(config.DefaultLayoutConfig(), fixedRandom{}, &fakeInput{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := game.EnableRewind(10); err == nil {
+		t.Fatalf("expected an error for a random source without state")
+	}
+}
@@ -0,0 +1,116 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+
+	"block-game/pkg/domain"
+	"block-game/pkg/level"
+	"block-game/pkg/save"
+)
+
+// ErrNotSavable is returned when a game cannot be suspended exactly.
+var ErrNotSavable = errors.New("game cannot be saved")
+
+// Save captures the game so that ResumeGame continues it step for step.
+// It fails once the game is over or when the random source cannot report its state.
+func (g *GameUsecase) Save() (*save.Game, error) {
+	if g.state.GameOver {
+		return nil, fmt.Errorf("%w: game is over", ErrNotSavable)
+	}
+	rnd, ok := g.rnd.(domain.StatefulRandom)
+	items, itemsOK := g.items.(domain.StatefulRandom)
+	if !ok || !itemsOK {
+		return nil, fmt.Errorf("%w: random source has no state", ErrNotSavable)
+	}
+	return &save.Game{
+		Version:    save.Version,
+		Difficulty: g.layout.Difficulty,
+		Layout:     g.layout,
+		State:      g.state.Clone(),
+		Random:     rnd.State(),
+		Items:      items.State(),
+	}, nil
+}
+
+// ResumeGame continues a saved single stage.
+func ResumeGame(s *save.Game, input InputPort) (*GameUsecase, error) {
+	if input == nil {
+		return nil, ErrNilInputPort
+	}
+	if s.Campaign != nil {
+		return nil, errors.New("save is a campaign run")
+	}
+	return resumeGame(s, domain.RestoreRandomSource(s.Random), input), nil
+}
+
+func resumeGame(s *save.Game, rnd domain.RandomSource, input InputPort) *GameUsecase {
+	state := s.State.Clone()
+	g := &GameUsecase{
+		state:  &state,
+		layout: s.Layout,
+		input:  input,
+		rnd:    rnd,
+		items:  domain.RestoreRandomSource(s.Items),
+		clock:  domain.NewFixedStep(s.Layout.StepDuration()),
+	}
+	g.capturePrevious()
+	return g
+}
+
+// Save captures the running stage together with the campaign's progress.
+// A cleared stage cannot be saved; advance to the next one first.
+func (c *Campaign) Save() (*save.Game, error) {
+	if c.tally != nil {
+		return nil, fmt.Errorf("%w: stage is cleared", ErrNotSavable)
+	}
+	s, err := c.game.Save()
+	if err != nil {
+		return nil, err
+	}
+	s.Campaign = &save.Campaign{
+		Stage:      c.stage,
+		Level:      c.levels[c.stage].Name,
+		Upgrades:   c.upgrades,
+		StartScore: c.startScore,
+		StartLives: c.startLives,
+
+		SharedRandom: c.rnd != nil,
+	}
+	return s, nil
+}
+
+// ResumeCampaign continues a saved campaign over the same levels.
+func ResumeCampaign(s *save.Game, levels []*level.Level, layout LayoutFunc, rules domain.StageRules, input InputPort) (*Campaign, error) {
+	if input == nil {
+		return nil, ErrNilInputPort
+	}
+	if s.Campaign == nil {
+		return nil, errors.New("save is not a campaign run")
+	}
+	sc := s.Campaign
+	if sc.Stage >= len(levels) {
+		return nil, fmt.Errorf("save is at stage %d but the campaign has %d", sc.Stage+1, len(levels))
+	}
+	if name := levels[sc.Stage].Name; name != sc.Level {
+		return nil, fmt.Errorf("save is at level %q but stage %d is %q", sc.Level, sc.Stage+1, name)
+	}
+
+	rnd := domain.RestoreRandomSource(s.Random)
+	game := resumeGame(s, rnd, input)
+	c := &Campaign{
+		levels:     levels,
+		layout:     layout,
+		rules:      rules,
+		input:      input,
+		stage:      sc.Stage,
+		game:       game,
+		upgrades:   sc.Upgrades,
+		startScore: sc.StartScore,
+		startLives: sc.StartLives,
+	}
+	if sc.SharedRandom {
+		c.rnd = rnd
+	}
+	return c, nil
+}
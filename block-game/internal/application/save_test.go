@@ -0,0 +1,160 @@
+package application
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"block-game/pkg/config"
+	"block-game/pkg/domain"
+	"block-game/pkg/level"
+	"block-game/pkg/save"
+)
+
+// saveRoundTrip encodes and decodes a save the way it would go through a file.
+func saveRoundTrip(t *testing.T, s *save.Game) *save.Game {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := save.Encode(&buf, s); err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	decoded, err := save.Decode(&buf)
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	return decoded
+}
+
+// fixedRandom is a RandomSource without a reportable state.
+type fixedRandom struct{}
+
+func (fixedRandom) Float64() float64 { return 0.5 }
+func (fixedRandom) Intn(n int) int   { return 0 }
+func (fixedRandom) Seed(int64)       {}
+
+func TestResumeGameMatchesUninterruptedRun(t *testing.T) {
+	cfg, _, err := config.LayoutWithDifficulty("HARD")
+	if err != nil {
+		t.Fatalf("layout: %v", err)
+	}
+	cfg.Drops.Chance = 0.8
+	seed := int64(11)
+	cfg.Seed = &seed
+
+	input := &scriptedInput{}
+	game, err := NewGameUsecase(cfg, domain.NewRandomSource(cfg.Seed), input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i := 0; i < 300; i++ {
+		game.Step()
+	}
+	s, err := game.Save()
+	if err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	resumed, err := ResumeGame(saveRoundTrip(t, s), &scriptedInput{tick: input.tick})
+	if err != nil {
+		t.Fatalf("resume: %v", err)
+	}
+	for !game.State().GameOver {
+		game.Step()
+		resumed.Step()
+	}
+	if game.State().Stats.ItemsDropped == 0 {
+		t.Fatalf("run should drop items so the random state matters")
+	}
+	assertSameState(t, resumed.State(), game.State())
+	if resumed.State().Stats != game.State().Stats {
+		t.Fatalf("stats differ: got %+v want %+v", resumed.State().Stats, game.State().Stats)
+	}
+}
+
+func TestResumeCampaignMatchesUninterruptedRun(t *testing.T) {
+	levels, err := level.Campaign(config.DefaultLayoutConfig())
+	if err != nil {
+		t.Fatalf("campaign: %v", err)
+	}
+	rules := config.DefaultStageRules()
+	seed := int64(5)
+
+	input := &scriptedInput{}
+	campaign, err := NewCampaign(levels, testLayoutFunc, rules, domain.NewRandomSource(&seed), input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	step := campaign.Game().Layout().StepDuration()
+	for i := 0; i < 300; i++ {
+		if err := campaign.Update(step); err != nil {
+			t.Fatalf("update: %v", err)
+		}
+	}
+	s, err := campaign.Save()
+	if err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	resumed, err := ResumeCampaign(saveRoundTrip(t, s), levels, testLayoutFunc, rules, &scriptedInput{tick: input.tick})
+	if err != nil {
+		t.Fatalf("resume: %v", err)
+	}
+	if resumed.Stage() != campaign.Stage() || resumed.Upgrades() != campaign.Upgrades() {
+		t.Fatalf("campaign progress not restored")
+	}
+
+	// The stage is finished and the next one played, so the tally and the shared
+	// random source are both carried over.
+	for _, c := range []*Campaign{campaign, resumed} {
+		clearStage(t, c, 0)
+		if err := c.NextStage(); err != nil {
+			t.Fatalf("next stage: %v", err)
+		}
+		for i := 0; i < 1800; i++ {
+			if err := c.Update(step); err != nil {
+				t.Fatalf("update: %v", err)
+			}
+		}
+	}
+	assertSameState(t, resumed.Game().State(), campaign.Game().State())
+}
+
+func TestSaveRejectsUnsavableGames(t *testing.T) {
+	cfg := config.DefaultLayoutConfig()
+	game, err := NewGameUsecase(cfg, fixedRandom{}, &fakeInput{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := game.Save(); !errors.Is(err, ErrNotSavable) {
+		t.Fatalf("expected ErrNotSavable for a stateless random source, got %v", err)
+	}
+
+	game, _ = NewGameUsecase(cfg, nil, &fakeInput{})
+	game.State().GameOver = true
+	if _, err := game.Save(); !errors.Is(err, ErrNotSavable) {
+		t.Fatalf("expected ErrNotSavable after game over, got %v", err)
+	}
+}
+
+func TestResumeChecksSaveKind(t *testing.T) {
+	game, _ := NewGameUsecase(config.DefaultLayoutConfig(), nil, &fakeInput{})
+	s, err := game.Save()
+	if err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	levels := testCampaignLevels(t, 2)
+	if _, err := ResumeCampaign(s, levels, testLayoutFunc, config.DefaultStageRules(), &fakeInput{}); err == nil {
+		t.Fatalf("expected an error resuming a single stage as a campaign")
+	}
+
+	s.Campaign = &save.Campaign{Stage: 1, Level: "another level"}
+	if _, err := ResumeGame(s, &fakeInput{}); err == nil {
+		t.Fatalf("expected an error resuming a campaign as a single stage")
+	}
+	if _, err := ResumeCampaign(s, levels, testLayoutFunc, config.DefaultStageRules(), &fakeInput{}); err == nil {
+		t.Fatalf("expected an error when the level at the saved stage changed")
+	}
+	s.Campaign.Stage = 5
+	if _, err := ResumeCampaign(s, levels, testLayoutFunc, config.DefaultStageRules(), &fakeInput{}); err == nil {
+		t.Fatalf("expected an error when the saved stage is past the campaign")
+	}
+}
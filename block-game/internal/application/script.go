@@ -0,0 +1,102 @@
+package application
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"block-game/pkg/domain"
+)
+
+// ScriptInput is an InputPort that plays a parsed input script, one entry per step.
+//
+// A script has one instruction per line: keys joined by '+' and an optional tick count,
+// e.g. "launch", "right 30", "left+launch 2", "fire 5" or "wait 10". Blank lines and lines starting
+// with '#' are ignored. A final "repeat" line loops the script forever.
+type ScriptInput struct {
+	inputs []domain.InputState
+	loop   bool
+	pos    int
+}
+
+// ParseScript reads a script, reporting errors by line number.
+func ParseScript(r io.Reader) (*ScriptInput, error) {
+	s := &ScriptInput{}
+	sc := bufio.NewScanner(r)
+	for line := 1; sc.Scan(); line++ {
+		text := strings.TrimSpace(sc.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		if s.loop {
+			return nil, fmt.Errorf("script line %d: nothing may follow repeat", line)
+		}
+		if text == "repeat" {
+			s.loop = true
+			continue
+		}
+
+		fields := strings.Fields(text)
+		if len(fields) > 2 {
+			return nil, fmt.Errorf("script line %d: want \"keys [ticks]\", got %q", line, text)
+		}
+		var in domain.InputState
+		for _, key := range strings.Split(fields[0], "+") {
+			switch key {
+			case "left":
+				in.MoveLeft = true
+			case "right":
+				in.MoveRight = true
+			case "launch":
+				in.Launch = true
+			case "fire":
+				in.Fire = true
+			case "wait":
+			default:
+				return nil, fmt.Errorf("script line %d: unknown key %q", line, key)
+			}
+		}
+		ticks := 1
+		if len(fields) == 2 {
+			n, err := strconv.Atoi(fields[1])
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("script line %d: tick count must be a positive integer, got %q", line, fields[1])
+			}
+			ticks = n
+		}
+		for i := 0; i < ticks; i++ {
+			s.inputs = append(s.inputs, in)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	if len(s.inputs) == 0 {
+		return nil, fmt.Errorf("script has no input")
+	}
+	return s, nil
+}
+
+func (s *ScriptInput) Read() domain.InputState {
+	if s.pos >= len(s.inputs) {
+		if !s.loop {
+			return domain.InputState{}
+		}
+		s.pos = 0
+	}
+	in := s.inputs[s.pos]
+	s.pos++
+	return in
+}
+
+// Done reports whether a non-repeating script has been played to the end.
+func (s *ScriptInput) Done() bool {
+	return !s.loop && s.pos >= len(s.inputs)
+}
+
+// Clone returns a copy of the script positioned at its first instruction.
+func (s *ScriptInput) Clone() *ScriptInput {
+	return &ScriptInput{inputs: s.inputs, loop: s.loop}
+}
@@ -0,0 +1,79 @@
+package application
+
+import (
+	"strings"
+	"testing"
+
+	"block-game/pkg/domain"
+)
+
+func TestParseScript(t *testing.T) {
+	script, err := ParseScript(strings.NewReader(`
+# serve, then sweep
+launch
+right 2
+left+launch
+fire
+wait 1
+`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []domain.InputState{
+		{Launch: true},
+		{MoveRight: true},
+		{MoveRight: true},
+		{MoveLeft: true, Launch: true},
+		{Fire: true},
+		{},
+	}
+	for i, w := range want {
+		if script.Done() {
+			t.Fatalf("script ended early at step %d", i)
+		}
+		if got := script.Read(); got != w {
+			t.Fatalf("step %d: got %+v want %+v", i, got, w)
+		}
+	}
+	if !script.Done() || script.Read() != (domain.InputState{}) {
+		t.Fatalf("finished script should be done and idle")
+	}
+}
+
+func TestParseScriptRepeat(t *testing.T) {
+	script, err := ParseScript(strings.NewReader("left\nright\nrepeat\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i := 0; i < 5; i++ {
+		got := script.Read()
+		if got.MoveLeft != (i%2 == 0) || script.Done() {
+			t.Fatalf("step %d: repeating script should alternate, got %+v", i, got)
+		}
+	}
+	clone := script.Clone()
+	if !clone.Read().MoveLeft {
+		t.Fatalf("clone should start at the first instruction")
+	}
+}
+
+func TestParseScriptErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		script string
+		want   string
+	}{
+		{"unknown key", "left\njump 3\n", "line 2: unknown key \"jump\""},
+		{"bad count", "right 0\n", "line 1: tick count must be a positive integer"},
+		{"after repeat", "left\nrepeat\nright\n", "line 3: nothing may follow repeat"},
+		{"empty", "# nothing\n", "no input"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseScript(strings.NewReader(tt.script))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
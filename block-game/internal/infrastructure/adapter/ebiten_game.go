@@ -1,17 +1,24 @@
 package adapter
 
 import (
+	"errors"
 	"fmt"
 	"image/color"
 	"log"
+	"os"
+	"time"
 
 	"block-game/internal/application"
 	"block-game/internal/infrastructure/view"
 	"block-game/pkg/config"
 	"block-game/pkg/domain"
+	"block-game/pkg/level"
+	"block-game/pkg/replay"
+	"block-game/pkg/save"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
 )
 
 type gameScene int
@@ -21,6 +28,18 @@ const (
 	scenePlaying
 	scenePaused
 	sceneGameOver
+	sceneStageIntro
+	sceneStageClear
+	sceneDebug // simulation halted for stepping and scrubbing through recent ticks
+)
+
+const (
+	// stageIntroDuration is how long the stage intro stays up before play starts on its own.
+	stageIntroDuration = 2 * time.Second
+	// attractDelay is how long the title screen waits for input before the autopilot demo starts.
+	attractDelay = 15 * time.Second
+	// rewindDuration is how far back the debug scene can step.
+	rewindDuration = 10 * time.Second
 )
 
 type EbitenGame struct {
@@ -40,6 +59,25 @@ type EbitenGame struct {
 	baseLayout     domain.LayoutConfig
 	input          application.InputPort
 	statusMsg      string
+	campaignMode   bool // title selection: bundled campaign or a single random stage
+	prevMode       bool
+	campaign       *application.Campaign
+	introElapsed   time.Duration
+	replayPath     string                // where the last run is saved and watched from; empty disables replays
+	recorder       *application.Recorder // records the run in progress
+	recordMode     replay.Mode
+	recordLayout   domain.LayoutConfig      // seeded layout the recorded run started from
+	player         *application.ReplayInput // non-nil while watching a replay
+	prevReplay     bool
+	titleIdle      time.Duration
+	demo           bool   // the autopilot is playing an attract-mode demo
+	savePath       string // where Save & Quit suspends the game; empty disables saving
+	hasSave        bool   // a suspended game is waiting at savePath
+	prevSave       bool
+	prevContinue   bool
+	prevDebug      bool
+	prevStepFwd    bool
+	prevStepBack   bool
 }
 
 func NewEbitenGame(input application.InputPort) *EbitenGame {
@@ -60,17 +98,55 @@ func NewEbitenGame(input application.InputPort) *EbitenGame {
 			domain.DifficultyNormal: "標準の設定",
 			domain.DifficultyHard:   "球が速くブロックが多い（チャレンジ）",
 		},
-		baseLayout: base,
-		input:      input,
+		baseLayout:   base,
+		input:        input,
+		campaignMode: true,
 	}
 }
 
 func (g *EbitenGame) Update() error {
 	switch g.scene {
 	case sceneTitle:
+		g.titleIdle += frameDuration()
+		if anyInput() {
+			g.titleIdle = 0
+		}
+		if g.titleIdle >= attractDelay {
+			if err := g.startDemo(); err != nil {
+				log.Printf("failed to start demo: %v", err)
+				g.titleIdle = 0
+			}
+			return nil
+		}
 		g.handleTitleInput()
 		g.handleTitleMouse()
+		if g.edgeMode() {
+			g.campaignMode = !g.campaignMode
+		}
+		if g.edgeReplay() {
+			if err := g.startReplay(); err != nil {
+				log.Printf("failed to load replay %s: %v", g.replayPath, err)
+				g.statusMsg = "no replay to watch: " + err.Error()
+			}
+			return nil
+		}
+		if g.edgeContinue() && g.hasSave {
+			if err := g.continueGame(); err != nil {
+				log.Printf("failed to resume %s: %v", g.savePath, err)
+				g.statusMsg = "cannot continue: " + err.Error()
+			}
+			return nil
+		}
 		if g.edgeEnterOrSpace() {
+			if g.campaignMode {
+				if err := g.startCampaign(); err != nil {
+					log.Printf("failed to start campaign with difficulty %s: %v", g.selectedDiff, err)
+					g.statusMsg = "campaign unavailable: " + err.Error()
+					return nil
+				}
+				g.scene = sceneStageIntro
+				return nil
+			}
 			if err := g.startGame(); err != nil {
 				log.Printf("failed to start game with difficulty %s: %v", g.selectedDiff, err)
 				return nil
@@ -78,21 +154,78 @@ func (g *EbitenGame) Update() error {
 			g.scene = scenePlaying
 		}
 		return nil
+	case sceneStageIntro:
+		g.introElapsed += frameDuration()
+		if g.edgeEnterOrSpace() || g.introElapsed >= stageIntroDuration {
+			g.scene = scenePlaying
+		}
+		return nil
 	case scenePlaying:
+		if g.demo {
+			if g.edgeEnterOrSpace() || anyInput() || g.usecase.State().GameOver {
+				g.resetToTitle()
+				return nil
+			}
+		}
 		if g.edgeEscape() {
+			if g.player != nil || g.demo {
+				g.resetToTitle()
+				return nil
+			}
 			g.scene = scenePaused
 			return nil
 		}
-		if err := g.usecase.Update(); err != nil {
+		if g.edgeDebug() && g.player == nil && !g.demo {
+			g.enterDebug()
+			return nil
+		}
+		g.pollInput()
+		if g.campaign != nil {
+			if err := g.campaign.Update(frameDuration()); err != nil {
+				return err
+			}
+			if g.campaign.Cleared() {
+				g.scene = sceneStageClear
+				return nil
+			}
+		} else if err := g.usecase.Update(frameDuration()); err != nil {
 			return err
 		}
-		if g.usecase.State().GameOver {
-			g.scene = sceneGameOver
+		if g.usecase.State().GameOver || (g.player != nil && g.player.Done()) {
+			g.endRun()
 		}
 		return nil
+	case sceneStageClear:
+		// replays move on by themselves; the recorded run read no input while waiting here
+		if g.player == nil && !g.edgeEnterOrSpace() {
+			return nil
+		}
+		if g.campaign.Completed() {
+			g.endRun()
+			return nil
+		}
+		if err := g.campaign.NextStage(); err != nil {
+			return err
+		}
+		g.enterStage()
+		return nil
+	case sceneDebug:
+		if g.edgeDebug() || g.edgeEscape() {
+			g.leaveDebug()
+			return nil
+		}
+		g.updateDebug()
+		return nil
 	case scenePaused:
 		if g.edgeEscape() {
 			g.scene = scenePlaying
+			return nil
+		}
+		if g.edgeSave() && g.savePath != "" {
+			if err := g.saveAndQuit(); err != nil {
+				log.Printf("failed to save game to %s: %v", g.savePath, err)
+				g.statusMsg = "save failed: " + err.Error()
+			}
 		}
 		return nil
 	case sceneGameOver:
@@ -113,7 +246,13 @@ func (g *EbitenGame) Draw(screen *ebiten.Image) {
 		if g.renderer == nil || g.usecase == nil {
 			return
 		}
-		g.renderer.Render(screen, g.usecase.State())
+		g.renderer.RenderInterpolated(screen, g.usecase.Previous(), g.usecase.State(), g.usecase.Alpha())
+		if g.player != nil {
+			ebitenutil.DebugPrintAt(screen, "REPLAY - Esc: Back to title", int(g.currentLayout().ScreenW)/2-80, 0)
+		}
+		if g.demo {
+			ebitenutil.DebugPrintAt(screen, "DEMO - Press any key", int(g.currentLayout().ScreenW)/2-60, 0)
+		}
 	case scenePaused:
 		if g.renderer == nil || g.usecase == nil {
 			return
@@ -126,6 +265,25 @@ func (g *EbitenGame) Draw(screen *ebiten.Image) {
 		}
 		g.renderer.Render(screen, g.usecase.State())
 		g.renderGameOverOverlay(screen)
+	case sceneDebug:
+		if g.renderer == nil || g.usecase == nil {
+			return
+		}
+		g.renderer.Render(screen, g.usecase.State())
+		g.renderer.RenderDebug(screen, g.usecase.State())
+		g.renderDebugOverlay(screen)
+	case sceneStageIntro:
+		if g.renderer == nil || g.usecase == nil {
+			return
+		}
+		g.renderer.Render(screen, g.usecase.State())
+		g.renderStageIntro(screen)
+	case sceneStageClear:
+		if g.renderer == nil || g.usecase == nil {
+			return
+		}
+		g.renderer.Render(screen, g.usecase.State())
+		g.renderStageClear(screen)
 	}
 }
 
@@ -158,6 +316,17 @@ func (g *EbitenGame) renderTitle(screen *ebiten.Image) {
 		ebitenutil.DebugPrintAt(screen, text, startX, lineY)
 	}
 
+	mode := "Random stage"
+	if g.campaignMode {
+		mode = "Campaign"
+	}
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Mode: %s (M: change)", mode), startX, startY+64)
+	if g.replayPath != "" {
+		ebitenutil.DebugPrintAt(screen, "R: Watch last replay", startX, startY+112)
+	}
+	if g.hasSave {
+		ebitenutil.DebugPrintAt(screen, "C: Continue saved game", startX, startY+128)
+	}
 	ebitenutil.DebugPrintAt(screen, prompt, startX, startY+80)
 	if g.statusMsg != "" {
 		ebitenutil.DebugPrintAt(screen, g.statusMsg, startX, startY+96)
@@ -175,6 +344,22 @@ func (g *EbitenGame) renderPauseOverlay(screen *ebiten.Image) {
 
 	ebitenutil.DebugPrintAt(screen, pauseTitle, startX, startY)
 	ebitenutil.DebugPrintAt(screen, diffLine, startX-24, startY+16)
+	ebitenutil.DebugPrintAt(screen, "Esc: Resume", startX-24, startY+32)
+	if g.savePath != "" {
+		ebitenutil.DebugPrintAt(screen, "S: Save & Quit", startX-24, startY+48)
+	}
+	if g.statusMsg != "" {
+		ebitenutil.DebugPrintAt(screen, g.statusMsg, startX-24, startY+64)
+	}
+}
+
+func (g *EbitenGame) renderDebugOverlay(screen *ebiten.Image) {
+	layout := g.currentLayout()
+	state := g.usecase.State()
+	startY := int(layout.ScreenH) - 48
+	status := fmt.Sprintf("DEBUG tick %d  rewind %d steps  contacts %d", state.Tick, g.usecase.RewindDepth(), len(state.Contacts))
+	ebitenutil.DebugPrintAt(screen, status, 0, startY)
+	ebitenutil.DebugPrintAt(screen, ".: Step  ,: Step back  Shift: Scrub  F3/Esc: Resume", 0, startY+16)
 }
 
 func (g *EbitenGame) renderGameOverOverlay(screen *ebiten.Image) {
@@ -182,9 +367,53 @@ func (g *EbitenGame) renderGameOverOverlay(screen *ebiten.Image) {
 	msg := "GAME OVER - Press Enter/Space to return"
 	startX := int(layout.ScreenW)/2 - 140
 	startY := int(layout.ScreenH)/2 + 32
+	if g.player != nil {
+		msg = "REPLAY ENDED - Press Enter/Space to return"
+	}
+	if g.campaign != nil && g.campaign.Completed() {
+		final := fmt.Sprintf("ALL %d STAGES CLEARED! Final score: %d", g.campaign.StageCount(), g.usecase.State().Score)
+		ebitenutil.DebugPrintAt(screen, final, startX, startY-16)
+	}
 	ebitenutil.DebugPrintAt(screen, msg, startX, startY)
 }
 
+func (g *EbitenGame) renderStageIntro(screen *ebiten.Image) {
+	layout := g.currentLayout()
+	startX := int(layout.ScreenW)/2 - 80
+	startY := int(layout.ScreenH)/2 + 32
+
+	title := fmt.Sprintf("STAGE %d/%d", g.campaign.Stage()+1, g.campaign.StageCount())
+	ebitenutil.DebugPrintAt(screen, title, startX, startY)
+	ebitenutil.DebugPrintAt(screen, g.campaign.Level().Name, startX, startY+16)
+	ebitenutil.DebugPrintAt(screen, "Enter/Space: Start", startX, startY+32)
+}
+
+func (g *EbitenGame) renderStageClear(screen *ebiten.Image) {
+	tally := g.campaign.Tally()
+	if tally == nil {
+		return
+	}
+	layout := g.currentLayout()
+	startX := int(layout.ScreenW)/2 - 80
+	startY := int(layout.ScreenH)/2 + 32
+
+	lines := []string{
+		fmt.Sprintf("Blocks:        %d", tally.BlockScore),
+		fmt.Sprintf("Clear bonus:   %d", tally.ClearBonus),
+		fmt.Sprintf("Lives bonus:   %d", tally.LifeBonus),
+	}
+	if tally.Perfect {
+		lines = append(lines, fmt.Sprintf("Perfect bonus: %d", tally.PerfectBonus))
+	}
+	if tally.Upgraded {
+		lines = append(lines, fmt.Sprintf("Paddle upgraded: +%.0f%%", g.campaign.Upgrades().PaddleWidthBonus*100))
+	}
+	lines = append(lines, fmt.Sprintf("Score:         %d", g.usecase.State().Score), "Enter/Space: Continue")
+	for i, line := range lines {
+		ebitenutil.DebugPrintAt(screen, line, startX, startY+16*i)
+	}
+}
+
 // handleTitleInput handles keyboard selection (up/down/left/right) on the title screen.
 func (g *EbitenGame) handleTitleInput() {
 	up := ebiten.IsKeyPressed(ebiten.KeyUp)
@@ -250,6 +479,36 @@ func (g *EbitenGame) edgeEscape() bool {
 	return esc && !g.prevEscape
 }
 
+func (g *EbitenGame) edgeMode() bool {
+	mode := ebiten.IsKeyPressed(ebiten.KeyM)
+	defer func() { g.prevMode = mode }()
+	return mode && !g.prevMode
+}
+
+func (g *EbitenGame) edgeReplay() bool {
+	r := ebiten.IsKeyPressed(ebiten.KeyR)
+	defer func() { g.prevReplay = r }()
+	return r && !g.prevReplay
+}
+
+func (g *EbitenGame) edgeSave() bool {
+	key := ebiten.IsKeyPressed(ebiten.KeyS)
+	defer func() { g.prevSave = key }()
+	return key && !g.prevSave
+}
+
+func (g *EbitenGame) edgeContinue() bool {
+	key := ebiten.IsKeyPressed(ebiten.KeyC)
+	defer func() { g.prevContinue = key }()
+	return key && !g.prevContinue
+}
+
+func (g *EbitenGame) edgeDebug() bool {
+	key := ebiten.IsKeyPressed(ebiten.KeyF3)
+	defer func() { g.prevDebug = key }()
+	return key && !g.prevDebug
+}
+
 func (g *EbitenGame) edgeEnterOrSpace() bool {
 	enterSpace := ebiten.IsKeyPressed(ebiten.KeyEnter) || ebiten.IsKeyPressed(ebiten.KeySpace)
 	defer func() { g.prevEnterSpace = enterSpace }()
@@ -260,7 +519,14 @@ func (g *EbitenGame) resetToTitle() {
 	g.scene = sceneTitle
 	g.usecase = nil
 	g.renderer = nil
+	g.campaign = nil
+	g.recorder = nil
+	g.player = nil
+	g.demo = false
+	g.titleIdle = 0
 	g.statusMsg = ""
+	g.hasSave = g.saveExists()
+	g.clearInput()
 }
 
 func (g *EbitenGame) startGame() error {
@@ -273,19 +539,304 @@ func (g *EbitenGame) startGame() error {
 	if applied != g.selectedDiff {
 		g.statusMsg = fmt.Sprintf("fallback to %s (invalid: %s)", applied, g.selectedDiff)
 	}
-	rnd := domain.NewRandomSource(layout.Seed)
-
-	usecase, err := application.NewGameUsecase(layout, rnd, g.input)
+	usecase, recorder, err := application.NewRecordedGame(layout, g.input)
 	if err != nil {
 		return err
 	}
+	enableRewind(usecase)
 
+	g.clearInput()
 	g.usecase = usecase
+	g.recorder = recorder
+	g.recordMode = replay.ModeStage
+	g.recordLayout = usecase.Layout()
 	g.renderer = view.NewRenderer(layout)
 	g.selectedDiff = applied
 	return nil
 }
 
+// startCampaign loads the bundled levels and starts the first stage at the selected difficulty.
+func (g *EbitenGame) startCampaign() error {
+	_, applied, err := config.LayoutWithDifficulty(string(g.selectedDiff))
+	if err != nil {
+		log.Printf("difficulty selection error: requested=%q fallback=%s err=%v", g.selectedDiff, applied, err)
+		g.statusMsg = fmt.Sprintf("fallback to %s (invalid: %s)", applied, g.selectedDiff)
+	}
+	levels, err := level.Campaign(g.baseLayout)
+	if err != nil {
+		return err
+	}
+	base := application.SeedLayout(g.baseLayout)
+	base.Difficulty = applied
+	recorder := application.NewRecorder(g.input)
+	rnd := domain.NewRandomSource(base.Seed)
+	campaign, err := application.NewCampaign(levels, campaignLayout(applied), config.DefaultStageRules(), rnd, recorder)
+	if err != nil {
+		return err
+	}
+
+	g.clearInput()
+	g.campaign = campaign
+	g.recorder = recorder
+	g.recordMode = replay.ModeCampaign
+	g.recordLayout = base
+	g.selectedDiff = applied
+	g.enterStage()
+	return nil
+}
+
+// campaignLayout builds each stage's layout at the given difficulty.
+func campaignLayout(difficulty domain.Difficulty) application.LayoutFunc {
+	return func(lvl *level.Level) (domain.LayoutConfig, error) {
+		layout, _, err := config.LayoutForLevel(lvl, string(difficulty))
+		return layout, err
+	}
+}
+
+// enterStage points the view at the campaign's current stage and shows its intro.
+// Replays skip the intro, which read no input in the recorded run either.
+func (g *EbitenGame) enterStage() {
+	g.usecase = g.campaign.Game()
+	if g.player == nil {
+		enableRewind(g.usecase)
+	}
+	g.renderer = view.NewRenderer(g.usecase.Layout())
+	g.introElapsed = 0
+	g.scene = sceneStageIntro
+	if g.player != nil {
+		g.scene = scenePlaying
+	}
+}
+
+// SetReplayPath sets the file the last run is saved to and watched from.
+func (g *EbitenGame) SetReplayPath(path string) {
+	g.replayPath = path
+}
+
+// endRun shows the game over scene and saves the recording of the run that just ended.
+func (g *EbitenGame) endRun() {
+	g.scene = sceneGameOver
+	if g.recorder == nil || g.replayPath == "" {
+		return
+	}
+	rep, err := g.recorder.Replay(g.recordMode, g.recordLayout)
+	if err == nil {
+		err = replay.Save(g.replayPath, rep)
+	}
+	if err != nil {
+		log.Printf("failed to save replay %s: %v", g.replayPath, err)
+	}
+	g.recorder = nil
+}
+
+// startDemo lets the autopilot play a generated stage at the selected difficulty.
+func (g *EbitenGame) startDemo() error {
+	layout, _, err := config.LayoutWithDifficulty(string(g.selectedDiff))
+	if err != nil {
+		return err
+	}
+	layout = application.SeedLayout(layout)
+	bot := application.NewAutopilot(application.SkillExpert, *layout.Seed)
+	usecase, err := application.NewGameUsecase(layout, domain.NewRandomSource(layout.Seed), bot)
+	if err != nil {
+		return err
+	}
+	bot.Observe(usecase.State(), usecase.Layout())
+
+	g.usecase = usecase
+	g.renderer = view.NewRenderer(layout)
+	g.demo = true
+	g.scene = scenePlaying
+	return nil
+}
+
+// framePoller is an input that latches per-frame key presses until a step reads them.
+type framePoller interface {
+	Poll()
+	Clear() // drops the latched presses
+}
+
+// pollInput lets the input see this frame's key presses, even if no step runs.
+// Replays and demos are driven by other inputs, so nothing is latched while they play.
+func (g *EbitenGame) pollInput() {
+	if g.player != nil || g.demo {
+		return
+	}
+	if p, ok := g.input.(framePoller); ok {
+		p.Poll()
+	}
+}
+
+// clearInput drops presses latched before a run starts, so that none leaks into it.
+func (g *EbitenGame) clearInput() {
+	if p, ok := g.input.(framePoller); ok {
+		p.Clear()
+	}
+}
+
+// anyInput reports a fresh key press or click.
+func anyInput() bool {
+	return len(inpututil.AppendJustPressedKeys(nil)) > 0 || inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft)
+}
+
+// startReplay loads the last saved run and plays it back.
+func (g *EbitenGame) startReplay() error {
+	if g.replayPath == "" {
+		return errors.New("replays are disabled")
+	}
+	rep, err := replay.Load(g.replayPath)
+	if err != nil {
+		return err
+	}
+	switch rep.Mode {
+	case replay.ModeCampaign:
+		levels, err := level.Campaign(rep.Layout)
+		if err != nil {
+			return err
+		}
+		campaign, player, err := application.NewReplayCampaign(rep, levels, campaignLayout(rep.Difficulty), config.DefaultStageRules())
+		if err != nil {
+			return err
+		}
+		g.campaign = campaign
+		g.player = player
+		g.enterStage()
+	default:
+		usecase, player, err := application.NewReplayGame(rep)
+		if err != nil {
+			return err
+		}
+		g.usecase = usecase
+		g.player = player
+		g.renderer = view.NewRenderer(usecase.Layout())
+		g.scene = scenePlaying
+	}
+	g.selectedDiff = rep.Difficulty
+	g.statusMsg = ""
+	return nil
+}
+
+// SetSavePath sets the file Save & Quit writes to and Continue resumes from.
+func (g *EbitenGame) SetSavePath(path string) {
+	g.savePath = path
+	g.hasSave = g.saveExists()
+}
+
+func (g *EbitenGame) saveExists() bool {
+	if g.savePath == "" {
+		return false
+	}
+	_, err := os.Stat(g.savePath)
+	return err == nil
+}
+
+// saveAndQuit suspends the game in progress to savePath and returns to the title.
+// The run's replay is dropped: a replay has to start from the seed, and this run will not.
+func (g *EbitenGame) saveAndQuit() error {
+	var (
+		s   *save.Game
+		err error
+	)
+	if g.campaign != nil {
+		s, err = g.campaign.Save()
+	} else {
+		s, err = g.usecase.Save()
+	}
+	if err != nil {
+		return err
+	}
+	if err := save.Save(g.savePath, s); err != nil {
+		return err
+	}
+	g.resetToTitle()
+	g.statusMsg = "game saved"
+	return nil
+}
+
+// continueGame resumes the suspended game paused, and removes the save so it is played once.
+func (g *EbitenGame) continueGame() error {
+	s, err := save.Load(g.savePath)
+	if err != nil {
+		return err
+	}
+	if s.Campaign != nil {
+		levels, err := level.Campaign(g.baseLayout)
+		if err != nil {
+			return err
+		}
+		campaign, err := application.ResumeCampaign(s, levels, campaignLayout(s.Difficulty), config.DefaultStageRules(), g.input)
+		if err != nil {
+			return err
+		}
+		g.campaign = campaign
+		g.usecase = campaign.Game()
+	} else {
+		usecase, err := application.ResumeGame(s, g.input)
+		if err != nil {
+			return err
+		}
+		g.usecase = usecase
+	}
+	if err := os.Remove(g.savePath); err != nil {
+		log.Printf("failed to remove save %s: %v", g.savePath, err)
+	}
+	g.hasSave = false
+	enableRewind(g.usecase)
+	g.renderer = view.NewRenderer(g.usecase.Layout())
+	g.selectedDiff = s.Difficulty
+	g.statusMsg = ""
+	g.scene = scenePaused
+	return nil
+}
+
+// enableRewind keeps the last rewindDuration of steps for the debug scene.
+func enableRewind(usecase *application.GameUsecase) {
+	steps := int(rewindDuration / usecase.Layout().StepDuration())
+	if err := usecase.EnableRewind(steps); err != nil {
+		log.Printf("rewind disabled: %v", err)
+	}
+}
+
+// enterDebug halts the simulation for stepping. The run stops being recorded: stepping
+// back does not un-read input, so its replay could no longer be played straight through.
+func (g *EbitenGame) enterDebug() {
+	g.recorder = nil
+	g.scene = sceneDebug
+}
+
+// leaveDebug resumes normal play. History keeps being recorded, so reopening the
+// debug scene can still step back past the point where it was left.
+func (g *EbitenGame) leaveDebug() {
+	g.scene = scenePlaying
+}
+
+// updateDebug steps one tick per key press, or one per frame while Shift is held.
+// Forward steps read live input, so the paddle can be steered while stepping.
+func (g *EbitenGame) updateDebug() {
+	fwd := ebiten.IsKeyPressed(ebiten.KeyPeriod)
+	back := ebiten.IsKeyPressed(ebiten.KeyComma)
+	scrub := ebiten.IsKeyPressed(ebiten.KeyShift)
+	defer func() { g.prevStepFwd, g.prevStepBack = fwd, back }()
+
+	if fwd && (scrub || !g.prevStepFwd) {
+		// latch only for the step taken now, so that nothing waits while halted
+		g.pollInput()
+		g.usecase.Step()
+	}
+	if back && (scrub || !g.prevStepBack) {
+		g.usecase.StepBack()
+	}
+}
+
+// frameDuration is the wall-clock time covered by one Ebiten Update call.
+func frameDuration() time.Duration {
+	tps := ebiten.TPS()
+	if tps <= 0 {
+		tps = ebiten.DefaultTPS
+	}
+	return time.Second / time.Duration(tps)
+}
+
 func (g *EbitenGame) currentLayout() domain.LayoutConfig {
 	if g.usecase != nil {
 		return g.usecase.Layout()
@@ -1,6 +1,7 @@
 package adapter
 
 import (
+	"path/filepath"
 	"testing"
 
 	"block-game/pkg/config"
@@ -53,3 +54,156 @@ func TestStartGameFallbackOnInvalidDifficulty(t *testing.T) {
 		t.Fatalf("expected status message on fallback, got empty")
 	}
 }
+
+func TestStartCampaignShowsFirstStageIntro(t *testing.T) {
+	game := NewEbitenGame(&fakeInput{})
+	game.selectedDiff = domain.DifficultyHard
+
+	if err := game.startCampaign(); err != nil {
+		t.Fatalf("startCampaign returned error: %v", err)
+	}
+	if game.campaign == nil || game.usecase != game.campaign.Game() || game.renderer == nil {
+		t.Fatalf("campaign not wired to the view")
+	}
+	if game.scene != sceneStageIntro || game.campaign.Stage() != 0 {
+		t.Fatalf("expected the first stage intro, got scene %d stage %d", game.scene, game.campaign.Stage())
+	}
+	if game.currentLayout().Difficulty != domain.DifficultyHard {
+		t.Fatalf("expected HARD difficulty, got %s", game.currentLayout().Difficulty)
+	}
+
+	game.resetToTitle()
+	if game.campaign != nil {
+		t.Fatalf("campaign should be dropped when returning to the title")
+	}
+}
+
+func TestEndRunSavesReplayThatCanBeWatched(t *testing.T) {
+	game := NewEbitenGame(&fakeInput{})
+	game.SetReplayPath(filepath.Join(t.TempDir(), "last.json"))
+
+	if err := game.startGame(); err != nil {
+		t.Fatalf("startGame returned error: %v", err)
+	}
+	for i := 0; i < 30; i++ {
+		game.usecase.Step()
+	}
+	game.endRun()
+	if game.scene != sceneGameOver {
+		t.Fatalf("expected game over scene, got %d", game.scene)
+	}
+	recordedTick := game.usecase.State().Tick
+
+	game.resetToTitle()
+	if err := game.startReplay(); err != nil {
+		t.Fatalf("startReplay returned error: %v", err)
+	}
+	if game.player == nil || game.scene != scenePlaying {
+		t.Fatalf("expected replay playback to start")
+	}
+	for !game.player.Done() {
+		game.usecase.Step()
+	}
+	if game.usecase.State().Tick != recordedTick {
+		t.Fatalf("replay should run the recorded %d ticks, ran %d", recordedTick, game.usecase.State().Tick)
+	}
+}
+
+func TestStartReplayWithoutFile(t *testing.T) {
+	game := NewEbitenGame(&fakeInput{})
+	game.SetReplayPath(filepath.Join(t.TempDir(), "missing.json"))
+	if err := game.startReplay(); err == nil {
+		t.Fatalf("expected an error without a saved replay")
+	}
+}
+
+func TestStartDemoLetsAutopilotPlay(t *testing.T) {
+	game := NewEbitenGame(&fakeInput{})
+	if err := game.startDemo(); err != nil {
+		t.Fatalf("startDemo returned error: %v", err)
+	}
+	if !game.demo || game.scene != scenePlaying || game.recorder != nil {
+		t.Fatalf("expected an unrecorded demo in play")
+	}
+	for i := 0; i < 120; i++ {
+		game.usecase.Step()
+	}
+	if len(game.usecase.State().Balls) > 0 && game.usecase.State().Balls[0].Attached {
+		t.Fatalf("the autopilot should have launched the ball")
+	}
+
+	game.resetToTitle()
+	if game.demo {
+		t.Fatalf("demo flag should be cleared on the title")
+	}
+}
+
+func TestSaveAndQuitThenContinue(t *testing.T) {
+	game := NewEbitenGame(&fakeInput{})
+	game.SetSavePath(filepath.Join(t.TempDir(), "save.json"))
+	if game.hasSave {
+		t.Fatalf("no save should be offered before saving")
+	}
+
+	if err := game.startCampaign(); err != nil {
+		t.Fatalf("startCampaign returned error: %v", err)
+	}
+	for i := 0; i < 30; i++ {
+		game.usecase.Step()
+	}
+	saved := *game.usecase.State()
+	game.scene = scenePaused
+	if err := game.saveAndQuit(); err != nil {
+		t.Fatalf("saveAndQuit returned error: %v", err)
+	}
+	if game.scene != sceneTitle || game.campaign != nil || !game.hasSave {
+		t.Fatalf("expected the title with a save to continue")
+	}
+
+	if err := game.continueGame(); err != nil {
+		t.Fatalf("continueGame returned error: %v", err)
+	}
+	if game.campaign == nil || game.usecase != game.campaign.Game() || game.scene != scenePaused {
+		t.Fatalf("expected the campaign to resume paused")
+	}
+	if got := game.usecase.State(); got.Tick != saved.Tick || got.Score != saved.Score || got.Paddle != saved.Paddle {
+		t.Fatalf("resumed state differs: got tick %d score %d, want tick %d score %d", got.Tick, got.Score, saved.Tick, saved.Score)
+	}
+	if game.hasSave || game.saveExists() {
+		t.Fatalf("the save should be used up once continued")
+	}
+}
+
+func TestEnterDebugStopsRecordingAndCanRewind(t *testing.T) {
+	game := NewEbitenGame(&fakeInput{})
+	if err := game.startGame(); err != nil {
+		t.Fatalf("startGame returned error: %v", err)
+	}
+	game.scene = scenePlaying
+	for i := 0; i < 20; i++ {
+		game.usecase.Step()
+	}
+
+	game.enterDebug()
+	if game.scene != sceneDebug || game.recorder != nil {
+		t.Fatalf("expected the debug scene without recording")
+	}
+	// the steps played before the pause can be undone
+	for i := 0; i < 5; i++ {
+		if !game.usecase.StepBack() {
+			t.Fatalf("step back %d failed with depth %d", i, game.usecase.RewindDepth())
+		}
+	}
+	if game.usecase.State().Tick != 15 {
+		t.Fatalf("expected to step back to tick 15, got %d", game.usecase.State().Tick)
+	}
+
+	game.leaveDebug()
+	for i := 0; i < 10; i++ {
+		game.usecase.Step()
+	}
+	game.enterDebug()
+	if game.usecase.RewindDepth() != 25 {
+		t.Fatalf("expected the history to last across debug sessions, got %d steps", game.usecase.RewindDepth())
+	}
+}
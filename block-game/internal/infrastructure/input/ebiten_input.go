@@ -4,17 +4,39 @@ import (
 	"block-game/pkg/domain"
 
 	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
 )
 
-type EbitenInputAdapter struct{}
+// EbitenInputAdapter reads the keyboard. A key press lasts a single Ebiten frame, but
+// frames that run no fixed step read no input, so Poll latches presses until Read.
+type EbitenInputAdapter struct {
+	launch bool // Space was pressed since the last Read
+}
 
 func NewEbitenInputAdapter() *EbitenInputAdapter {
 	return &EbitenInputAdapter{}
 }
 
+// Poll records the key presses of the current frame. Call it once per frame.
+func (e *EbitenInputAdapter) Poll() {
+	if inpututil.IsKeyJustPressed(ebiten.KeySpace) {
+		e.launch = true
+	}
+}
+
+// Clear drops the latched presses, e.g. a Space pressed on a menu before a run starts.
+func (e *EbitenInputAdapter) Clear() {
+	e.launch = false
+}
+
+// Read returns the keys held now and consumes the latched presses.
 func (e *EbitenInputAdapter) Read() domain.InputState {
-	return domain.InputState{
+	in := domain.InputState{
 		MoveLeft:  ebiten.IsKeyPressed(ebiten.KeyLeft),
 		MoveRight: ebiten.IsKeyPressed(ebiten.KeyRight),
+		Launch:    e.launch,
+		Fire:      ebiten.IsKeyPressed(ebiten.KeySpace),
 	}
+	e.launch = false
+	return in
 }
@@ -9,3 +9,23 @@ func TestNewEbitenInputAdapter(t *testing.T) {
 		t.Fatalf("expected non-nil adapter")
 	}
 }
+
+func TestLatchedLaunchIsReadOnce(t *testing.T) {
+	e := NewEbitenInputAdapter()
+	e.launch = true
+	if !e.Read().Launch {
+		t.Fatalf("expected the latched press to launch")
+	}
+	if e.Read().Launch {
+		t.Fatalf("expected the press to be consumed by the first read")
+	}
+}
+
+func TestClearDropsLatchedLaunch(t *testing.T) {
+	e := NewEbitenInputAdapter()
+	e.launch = true
+	e.Clear()
+	if e.Read().Launch {
+		t.Fatalf("expected a cleared press not to launch")
+	}
+}
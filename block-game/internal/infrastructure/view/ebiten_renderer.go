@@ -3,6 +3,7 @@ package view
 import (
 	"fmt"
 	"image/color"
+	"math"
 
 	"block-game/pkg/domain"
 
@@ -12,6 +13,7 @@ import (
 
 type Renderer struct {
 	layout domain.LayoutConfig
+	pixel  *ebiten.Image // 1x1 white image used to draw rotated blocks
 }
 
 func NewRenderer(layout domain.LayoutConfig) *Renderer {
@@ -26,59 +28,237 @@ func (r *Renderer) Render(screen *ebiten.Image, state *domain.GameState) {
 
 	for _, item := range state.Items {
 		if item.Active {
-			var itemColor color.RGBA
-			switch item.Type {
-			case domain.ItemTypeMultiball:
-				itemColor = color.RGBA{255, 200, 50, 255} // yellow/orange
-			case domain.ItemTypePaddleEnlarge:
-				itemColor = color.RGBA{50, 255, 100, 255} // green
-			default:
-				itemColor = color.RGBA{255, 200, 50, 255}
-			}
-			ebitenutil.DrawRect(screen, item.X, item.Y, item.Width, item.Height, itemColor)
+			ebitenutil.DrawRect(screen, item.X, item.Y, item.Width, item.Height, itemColor(item.Type))
 		}
 	}
 
 	for _, block := range state.Blocks {
 		if block.Alive {
-			ebitenutil.DrawRect(screen, block.X, block.Y, r.layout.BlockW, r.layout.BlockH, color.RGBA{100, 200, 255, 255})
-			ebitenutil.DrawRect(screen, block.X+2, block.Y+2, r.layout.BlockW-4, r.layout.BlockH-4, color.RGBA{50, 150, 255, 255})
+			r.drawBlock(screen, block)
 		}
 	}
 
-	// Draw paddle with color change when effect is active
+	// Draw paddle with color change while an effect resizes it or makes it sticky
 	paddleColor := color.RGBA{255, 255, 255, 255} // white (normal)
-	if state.PaddleEffect.Active {
-		paddleColor = color.RGBA{0, 255, 255, 255} // cyan (enlarged)
+	if state.Modifier(domain.ModPaddleWidth) != 1 {
+		paddleColor = color.RGBA{0, 255, 255, 255} // cyan (resized)
+	}
+	if _, ok := state.Effect(domain.EffectCatch); ok {
+		paddleColor = color.RGBA{190, 110, 255, 255} // purple (catch)
 	}
 	ebitenutil.DrawRect(screen, state.Paddle.X, state.Paddle.Y, state.Paddle.Width, state.Paddle.Height, paddleColor)
 
+	if b := state.Barrier; b.Active {
+		ebitenutil.DrawRect(screen, 0, b.Y, r.layout.ScreenW, b.Height, color.RGBA{80, 200, 255, 255})
+	}
+
 	for _, ball := range state.Balls {
+		if ball.Piercing {
+			drawFireTrail(screen, ball)
+			ebitenutil.DrawCircle(screen, ball.X, ball.Y, ball.Radius, color.RGBA{255, 120, 30, 255})
+			continue
+		}
 		ebitenutil.DrawCircle(screen, ball.X, ball.Y, ball.Radius, color.RGBA{255, 255, 0, 255})
 	}
 
+	for _, p := range state.Projectiles {
+		ebitenutil.DrawRect(screen, p.X, p.Y, p.Width, p.Height, color.RGBA{255, 80, 80, 255})
+	}
+
 	scoreText := "Score: " + fmt.Sprintf("%d", state.Score)
 	ebitenutil.DebugPrintAt(screen, scoreText, 0, 16)
+	livesText := fmt.Sprintf("Lives: %d", state.Lives)
+	ebitenutil.DebugPrintAt(screen, livesText, int(r.layout.ScreenW)-80, 0)
 
-	// Show paddle effect indicator
-	if state.PaddleEffect.Active {
-		remainingSec := float64(state.PaddleEffect.RemainingTicks) / 60.0
-		effectText := fmt.Sprintf("PADDLE x%.0f (%.1fs)", state.PaddleEffect.Multiplier, remainingSec)
-		ebitenutil.DebugPrintAt(screen, effectText, 0, 32)
+	if !state.GameOver && hasAttachedBall(state) {
+		ebitenutil.DebugPrintAt(screen, "Space: Launch", int(r.layout.ScreenW)/2-40, int(r.layout.ScreenH)/2)
+	}
+
+	// List the active effects with their timers, one per line
+	for i, effect := range state.Effects {
+		ebitenutil.DebugPrintAt(screen, r.effectLabel(effect), 0, 32+16*i)
 	}
 
 	if state.GameOver {
 		gameOverText := "GAME OVER"
-		allBlocksDestroyed := true
-		for _, block := range state.Blocks {
-			if block.Alive {
-				allBlocksDestroyed = false
-				break
+		if state.Cleared {
+			gameOverText = "STAGE CLEAR!"
+		}
+		ebitenutil.DebugPrintAt(screen, gameOverText, int(r.layout.ScreenW)/2-50, int(r.layout.ScreenH)/2)
+	}
+}
+
+// effectLabel formats an active effect for the HUD, e.g. "PADDLE x3 (4.2s)".
+func (r *Renderer) effectLabel(effect domain.ActiveEffect) string {
+	name := fmt.Sprintf("EFFECT %d", effect.Kind)
+	spec, ok := domain.EffectSpec(effect.Kind)
+	if ok && spec.Name != "" {
+		name = spec.Name
+	}
+	remainingSec := float64(effect.RemainingTicks) * r.layout.StepSeconds()
+	if ok && spec.Modifies != domain.ModNone {
+		return fmt.Sprintf("%s x%g (%.1fs)", name, effect.Scale(), remainingSec)
+	}
+	return fmt.Sprintf("%s (%.1fs)", name, remainingSec)
+}
+
+// drawBlock draws a block using its own bounds and shape.
+func (r *Renderer) drawBlock(screen *ebiten.Image, block domain.Block) {
+	border, fill := blockColors(block)
+	switch block.Shape {
+	case domain.BlockShapeCircle:
+		cx, cy := block.Center()
+		radius := math.Min(block.W, block.H) / 2
+		ebitenutil.DrawCircle(screen, cx, cy, radius, border)
+		ebitenutil.DrawCircle(screen, cx, cy, radius-2, fill)
+	case domain.BlockShapeRotatedRect:
+		r.drawRotatedRect(screen, block, block.W, block.H, border)
+		r.drawRotatedRect(screen, block, block.W-4, block.H-4, fill)
+	default:
+		ebitenutil.DrawRect(screen, block.X, block.Y, block.W, block.H, border)
+		ebitenutil.DrawRect(screen, block.X+2, block.Y+2, block.W-4, block.H-4, fill)
+		if block.MaxHP > 1 && block.HP < block.MaxHP {
+			// 損傷状態: 失った HP に応じてひびを描く
+			for i := 0; i < block.MaxHP-block.HP; i++ {
+				crackX := block.X + block.W*float64(i+1)/float64(block.MaxHP)
+				ebitenutil.DrawLine(screen, crackX, block.Y+2, crackX-6, block.Y+block.H-2, border)
 			}
 		}
-		if allBlocksDestroyed {
-			gameOverText = "YOU WIN!"
+	}
+}
+
+// drawRotatedRect draws a w x h rectangle centred on the block and rotated by its angle.
+func (r *Renderer) drawRotatedRect(screen *ebiten.Image, block domain.Block, w, h float64, c color.RGBA) {
+	if r.pixel == nil {
+		r.pixel = ebiten.NewImage(1, 1)
+		r.pixel.Fill(color.White)
+	}
+	cx, cy := block.Center()
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Scale(w, h)
+	op.GeoM.Translate(-w/2, -h/2)
+	op.GeoM.Rotate(block.Angle)
+	op.GeoM.Translate(cx, cy)
+	op.ColorScale.ScaleWithColor(c)
+	screen.DrawImage(r.pixel, op)
+}
+
+// blockColors returns the border and fill colors of a block by type,
+// with the fill dimmed as the block loses HP.
+func blockColors(block domain.Block) (color.RGBA, color.RGBA) {
+	var border, fill color.RGBA
+	switch block.Type {
+	case domain.BlockTypeHard:
+		border, fill = color.RGBA{200, 200, 220, 255}, color.RGBA{130, 130, 160, 255} // silver
+	case domain.BlockTypeIndestructible:
+		border, fill = color.RGBA{160, 140, 60, 255}, color.RGBA{110, 95, 40, 255} // gold
+	case domain.BlockTypeExplosive:
+		border, fill = color.RGBA{255, 140, 80, 255}, color.RGBA{230, 60, 30, 255} // red
+	case domain.BlockTypeItem:
+		border, fill = color.RGBA{200, 255, 150, 255}, color.RGBA{90, 200, 60, 255} // green
+	default:
+		border, fill = color.RGBA{100, 200, 255, 255}, color.RGBA{50, 150, 255, 255} // blue
+	}
+	if block.MaxHP > 1 && block.HP < block.MaxHP {
+		ratio := 0.4 + 0.6*float64(block.HP)/float64(block.MaxHP)
+		fill = color.RGBA{uint8(float64(fill.R) * ratio), uint8(float64(fill.G) * ratio), uint8(float64(fill.B) * ratio), 255}
+	}
+	return border, fill
+}
+
+// Fireball trail: fireTrailLength fading circles, fireTrailStep seconds apart along the path.
+const (
+	fireTrailLength = 4
+	fireTrailStep   = 0.015
+)
+
+// drawFireTrail draws shrinking, darkening circles behind a piercing ball.
+func drawFireTrail(screen *ebiten.Image, ball domain.Ball) {
+	for i := fireTrailLength; i >= 1; i-- {
+		t := float64(i) * fireTrailStep
+		fade := 1 - float64(i)/float64(fireTrailLength+1)
+		c := color.RGBA{uint8(255 * fade), uint8(60 * fade), 0, 255}
+		ebitenutil.DrawCircle(screen, ball.X-ball.VX*t, ball.Y-ball.VY*t, ball.Radius*(0.5+0.5*fade), c)
+	}
+}
+
+// itemColor returns the color an item type declares, yellow for unregistered types.
+func itemColor(t domain.ItemType) color.RGBA {
+	spec, ok := domain.ItemSpec(t)
+	if !ok {
+		return color.RGBA{255, 200, 50, 255}
+	}
+	return color.RGBA{spec.Color[0], spec.Color[1], spec.Color[2], 255}
+}
+
+func hasAttachedBall(state *domain.GameState) bool {
+	for _, ball := range state.Balls {
+		if ball.Attached {
+			return true
 		}
-		ebitenutil.DebugPrintAt(screen, gameOverText, int(r.layout.ScreenW)/2-50, int(r.layout.ScreenH)/2)
+	}
+	return false
+}
+
+// RenderInterpolated draws the state blended between prev and curr by alpha (0 = prev, 1 = curr).
+// Entities whose count changed during the step are drawn at their current positions.
+func (r *Renderer) RenderInterpolated(screen *ebiten.Image, prev, curr *domain.GameState, alpha float64) {
+	if prev == nil {
+		r.Render(screen, curr)
+		return
+	}
+
+	frame := *curr
+	frame.Paddle.X = lerp(prev.Paddle.X, curr.Paddle.X, alpha)
+	if len(prev.Balls) == len(curr.Balls) {
+		frame.Balls = make([]domain.Ball, len(curr.Balls))
+		for i, b := range curr.Balls {
+			b.X = lerp(prev.Balls[i].X, b.X, alpha)
+			b.Y = lerp(prev.Balls[i].Y, b.Y, alpha)
+			frame.Balls[i] = b
+		}
+	}
+	if len(prev.Items) == len(curr.Items) {
+		frame.Items = make([]domain.Item, len(curr.Items))
+		for i, item := range curr.Items {
+			item.Y = lerp(prev.Items[i].Y, item.Y, alpha)
+			frame.Items[i] = item
+		}
+	}
+	if len(prev.Projectiles) == len(curr.Projectiles) {
+		frame.Projectiles = make([]domain.Projectile, len(curr.Projectiles))
+		for i, p := range curr.Projectiles {
+			p.Y = lerp(prev.Projectiles[i].Y, p.Y, alpha)
+			frame.Projectiles[i] = p
+		}
+	}
+	r.Render(screen, &frame)
+}
+
+func lerp(a, b, t float64) float64 {
+	return a + (b-a)*t
+}
+
+// velocityScale is the time in seconds a debug velocity vector covers.
+const velocityScale = 0.1
+
+// RenderDebug overlays each ball's velocity and the contacts resolved in the latest step.
+// Block contacts are red, wall and paddle contacts blue; the line is the surface normal.
+func (r *Renderer) RenderDebug(screen *ebiten.Image, state *domain.GameState) {
+	velocityColor := color.RGBA{255, 0, 255, 255}
+	for i, ball := range state.Balls {
+		ebitenutil.DrawLine(screen, ball.X, ball.Y, ball.X+ball.VX*velocityScale, ball.Y+ball.VY*velocityScale, velocityColor)
+		speed := math.Hypot(ball.VX, ball.VY)
+		label := fmt.Sprintf("#%d v=(%.1f, %.1f) |v|=%.1f", i, ball.VX, ball.VY, speed)
+		ebitenutil.DebugPrintAt(screen, label, int(ball.X+ball.Radius)+4, int(ball.Y)-8)
+	}
+
+	for _, c := range state.Contacts {
+		contactColor := color.RGBA{80, 160, 255, 255}
+		if c.Block >= 0 {
+			contactColor = color.RGBA{255, 60, 60, 255}
+		}
+		ebitenutil.DrawRect(screen, c.X-2, c.Y-2, 4, 4, contactColor)
+		ebitenutil.DrawLine(screen, c.X, c.Y, c.X+c.NX*16, c.Y+c.NY*16, contactColor)
 	}
 }
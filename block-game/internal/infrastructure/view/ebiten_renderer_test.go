@@ -2,6 +2,7 @@ package view
 
 import (
 	"image"
+	"image/color"
 	"testing"
 
 	"block-game/pkg/config"
@@ -47,9 +48,14 @@ func TestRendererRenderDoesNotPanic(t *testing.T) {
 	renderer := NewRenderer(cfg)
 
 	state := domain.NewGameState(cfg, []domain.Block{
-		{X: 10, Y: 10, Alive: true},
+		{X: 10, Y: 10, W: cfg.BlockW, H: cfg.BlockH, Alive: true},
+		{X: 100, Y: 10, W: 40, H: 40, Shape: domain.BlockShapeCircle, Alive: true},
+		{X: 200, Y: 10, W: 60, H: 20, Shape: domain.BlockShapeRotatedRect, Angle: 0.3, Alive: true},
 	})
 	state.GameOver = true
+	fireball := state.Balls[0]
+	fireball.Piercing, fireball.VX, fireball.VY = true, 200, -200
+	state.Balls = append(state.Balls, fireball)
 
 	screen := ebiten.NewImage(int(cfg.ScreenW), int(cfg.ScreenH))
 	defer screen.Dispose()
@@ -57,3 +63,100 @@ func TestRendererRenderDoesNotPanic(t *testing.T) {
 	renderer.Render(screen, state)
 	// no assertion: absence of panic is success
 }
+
+func TestRenderInterpolatedDoesNotPanic(t *testing.T) {
+	cfg := config.DefaultLayoutConfig()
+	renderer := NewRenderer(cfg)
+
+	prev := domain.NewGameState(cfg, []domain.Block{})
+	curr := domain.NewGameState(cfg, []domain.Block{})
+	curr.Balls = append(curr.Balls, curr.Balls[0])
+	prev.Projectiles = []domain.Projectile{{X: 100, Y: 300, Width: 4, Height: 12}}
+	curr.Projectiles = []domain.Projectile{{X: 100, Y: 290, Width: 4, Height: 12}}
+
+	screen := ebiten.NewImage(int(cfg.ScreenW), int(cfg.ScreenH))
+	defer screen.Dispose()
+
+	renderer.RenderInterpolated(screen, prev, curr, 0.5)
+	renderer.RenderInterpolated(screen, nil, curr, 0.5)
+}
+
+func TestLerp(t *testing.T) {
+	if got := lerp(10, 20, 0.25); got != 12.5 {
+		t.Fatalf("expected 12.5, got %f", got)
+	}
+}
+
+func TestHasAttachedBall(t *testing.T) {
+	cfg := config.DefaultLayoutConfig()
+	state := domain.NewGameState(cfg, []domain.Block{})
+	if !hasAttachedBall(state) {
+		t.Fatalf("expected served ball to be attached")
+	}
+	state.Balls[0].Attached = false
+	if hasAttachedBall(state) {
+		t.Fatalf("expected no attached ball after launch")
+	}
+}
+
+func TestBlockColorsDimWithDamage(t *testing.T) {
+	full := domain.NewBlock(0, 0, 70, 30, domain.BlockTypeHard)
+	damaged := full
+	damaged.HP = 1
+
+	_, fullFill := blockColors(full)
+	_, damagedFill := blockColors(damaged)
+	if damagedFill.R >= fullFill.R {
+		t.Fatalf("expected damaged block to be darker: %v vs %v", damagedFill, fullFill)
+	}
+
+	_, normalFill := blockColors(domain.NewBlock(0, 0, 70, 30, domain.BlockTypeNormal))
+	if normalFill == fullFill {
+		t.Fatalf("expected block types to have distinct colors")
+	}
+}
+
+func TestItemColorComesFromSpec(t *testing.T) {
+	spec, _ := domain.ItemSpec(domain.ItemTypePaddleEnlarge)
+	got := itemColor(domain.ItemTypePaddleEnlarge)
+	if got != (color.RGBA{spec.Color[0], spec.Color[1], spec.Color[2], 255}) {
+		t.Fatalf("expected the spec color, got %v", got)
+	}
+	if itemColor(domain.ItemType(99)) != (color.RGBA{255, 200, 50, 255}) {
+		t.Fatalf("expected the fallback color for an unregistered item")
+	}
+}
+
+func TestRenderDebugDoesNotPanic(t *testing.T) {
+	cfg := config.DefaultLayoutConfig()
+	renderer := NewRenderer(cfg)
+
+	state := domain.NewGameState(cfg, []domain.Block{})
+	state.Contacts = []domain.Contact{
+		{X: 100, Y: 100, NX: 0, NY: 1, Block: 0},
+		{X: 10, Y: 200, NX: 1, NY: 0, Block: -1},
+	}
+
+	screen := ebiten.NewImage(int(cfg.ScreenW), int(cfg.ScreenH))
+	defer screen.Dispose()
+
+	renderer.RenderDebug(screen, state)
+}
+
+func TestEffectLabel(t *testing.T) {
+	cfg := config.DefaultLayoutConfig()
+	renderer := NewRenderer(cfg)
+
+	tests := []struct {
+		effect domain.ActiveEffect
+		want   string
+	}{
+		{domain.ActiveEffect{Kind: domain.EffectPaddleEnlarge, RemainingTicks: 90, Stacks: 1, Magnitude: 3}, "PADDLE x3 (1.5s)"},
+		{domain.ActiveEffect{Kind: domain.EffectKind(99), RemainingTicks: 30, Stacks: 1, Magnitude: 1}, "EFFECT 99 (0.5s)"},
+	}
+	for _, tt := range tests {
+		if got := renderer.effectLabel(tt.effect); got != tt.want {
+			t.Fatalf("effectLabel(%+v) = %q, want %q", tt.effect, got, tt.want)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package config
 
 import (
 	"block-game/pkg/domain"
+	"block-game/pkg/level"
 	"strings"
 )
 
@@ -16,22 +17,68 @@ const (
 	PaddleWidth       = 100
 	PaddleHeight      = 20
 	PaddleY           = ScreenHeight - 50
-	PaddleSpeed       = 5.0
+	PaddleSpeed       = 300.0 // px/s
 	BallRadius        = 10
-	BallSpeed         = 5.0
+	BallSpeed         = 300.0 // px/s
 	MinPaddleGap      = 180.0
 	MaxAttemptsFactor = 10
-	ItemDropChance    = 0.1
+	ItemDropChance    = 0.12 // per destroyed block; the item is picked by the item weights
 	ItemMaxCount      = 3
-	ItemFallSpeed     = 3.0
+	ItemFallSpeed     = 180.0 // px/s
 	ItemWidth         = 16.0
 	ItemHeight        = 12.0
 	MaxBalls          = 8
+	TickRate          = 60 // fixed simulation steps per second
+	Lives             = 3
 
 	// Paddle-enlarge item settings
-	PaddleEnlargeChance     = 0.02 // 2% probability
-	PaddleEnlargeDuration   = 300  // 5 seconds @ 60FPS
-	PaddleEnlargeMultiplier = 3.0  // 3x paddle width
+	PaddleEnlargeDuration   = 5.0 // seconds
+	PaddleEnlargeMultiplier = 3.0 // 3x paddle width
+
+	// Laser item settings
+	LaserDuration = 8.0   // seconds
+	LaserCooldown = 0.35  // seconds between shots
+	LaserSpeed    = 600.0 // px/s
+	LaserWidth    = 4.0
+	LaserHeight   = 12.0
+
+	// Catch item settings
+	CatchDuration = 10.0 // seconds
+
+	// Fireball item settings
+	FireballDuration = 6.0 // seconds
+
+	// Harmful item settings; their drop weights depend on the difficulty
+	PaddleShrinkDuration    = 8.0 // seconds
+	PaddleShrinkMultiplier  = 0.6
+	BallSpeedUpDuration     = 8.0 // seconds
+	BallSpeedUpMultiplier   = 1.3
+	ReverseControlsDuration = 6.0 // seconds
+
+	// Slow item settings
+	BallSlowDuration   = 8.0 // seconds
+	BallSlowMultiplier = 0.6
+
+	// Extra-life and barrier item settings
+	MaxLives      = 5
+	BarrierHeight = 6.0
+
+	// Relative weights of generated block types
+	NormalBlockWeight         = 0.75
+	HardBlockWeight           = 0.12
+	IndestructibleBlockWeight = 0.03
+	ExplosiveBlockWeight      = 0.05
+	ItemBlockWeight           = 0.05
+
+	// Campaign stage progression
+	StageBallSpeedStep   = 0.05 // +5% ball speed per stage
+	StagePaddleWidthStep = 0.03 // -3% paddle width per stage
+	StageMaxRamp         = 8
+	StageClearBonus      = 10 // per stage number
+	StageLifeBonus       = 5  // per remaining life
+	StagePerfectBonus    = 20
+	PerfectPaddleBonus   = 0.1 // +10% paddle width for a perfect clear
+	MaxPaddleBonus       = 0.3
 )
 
 func DefaultLayoutConfig() domain.LayoutConfig {
@@ -53,23 +100,70 @@ func DefaultLayoutConfig() domain.LayoutConfig {
 		MinPaddleGap:            MinPaddleGap,
 		MaxAttempts:             MaxAttemptsFactor * BlockRows * BlockCols,
 		MaxBalls:                MaxBalls,
-		ItemDropChance:          ItemDropChance,
+		Drops:                   domain.DropTable{Chance: ItemDropChance},
 		MaxItems:                ItemMaxCount,
 		ItemWidth:               ItemWidth,
 		ItemHeight:              ItemHeight,
 		ItemFallSpeed:           ItemFallSpeed,
-		PaddleEnlargeChance:     PaddleEnlargeChance,
 		PaddleEnlargeDuration:   PaddleEnlargeDuration,
 		PaddleEnlargeMultiplier: PaddleEnlargeMultiplier,
-		Difficulty:              domain.DifficultyNormal,
-		Seed:                    nil,
+		LaserDuration:           LaserDuration,
+		LaserCooldown:           LaserCooldown,
+		LaserSpeed:              LaserSpeed,
+		LaserWidth:              LaserWidth,
+		LaserHeight:             LaserHeight,
+		CatchDuration:           CatchDuration,
+		FireballDuration:        FireballDuration,
+		PaddleShrinkDuration:    PaddleShrinkDuration,
+		PaddleShrinkMultiplier:  PaddleShrinkMultiplier,
+		BallSpeedUpDuration:     BallSpeedUpDuration,
+		BallSpeedUpMultiplier:   BallSpeedUpMultiplier,
+		ReverseControlsDuration: ReverseControlsDuration,
+		BallSlowDuration:        BallSlowDuration,
+		BallSlowMultiplier:      BallSlowMultiplier,
+		TickRate:                TickRate,
+		Lives:                   Lives,
+		MaxLives:                MaxLives,
+		BarrierHeight:           BarrierHeight,
+		BlockTypeWeights: map[domain.BlockType]float64{
+			domain.BlockTypeNormal:         NormalBlockWeight,
+			domain.BlockTypeHard:           HardBlockWeight,
+			domain.BlockTypeIndestructible: IndestructibleBlockWeight,
+			domain.BlockTypeExplosive:      ExplosiveBlockWeight,
+			domain.BlockTypeItem:           ItemBlockWeight,
+		},
+		Difficulty: domain.DifficultyNormal,
+		Seed:       nil,
+	}
+}
+
+// DefaultStageRules returns the campaign ramp and clear bonuses.
+func DefaultStageRules() domain.StageRules {
+	return domain.StageRules{
+		BallSpeedStep:      StageBallSpeedStep,
+		PaddleWidthStep:    StagePaddleWidthStep,
+		MaxRampStages:      StageMaxRamp,
+		ClearBonus:         StageClearBonus,
+		LifeBonus:          StageLifeBonus,
+		PerfectBonus:       StagePerfectBonus,
+		PerfectPaddleBonus: PerfectPaddleBonus,
+		MaxPaddleBonus:     MaxPaddleBonus,
 	}
 }
 
 // LayoutWithDifficulty returns a LayoutConfig with the requested difficulty applied.
 // If the requested difficulty is invalid, it falls back to the default.
 func LayoutWithDifficulty(selected string) (domain.LayoutConfig, domain.Difficulty, error) {
-	base := DefaultLayoutConfig()
+	return layoutWithDifficulty(DefaultLayoutConfig(), selected)
+}
+
+// LayoutForLevel returns the default layout with the level's overrides applied,
+// then the requested difficulty on top, falling back to the default difficulty like LayoutWithDifficulty.
+func LayoutForLevel(lvl *level.Level, selected string) (domain.LayoutConfig, domain.Difficulty, error) {
+	return layoutWithDifficulty(lvl.ApplyTo(DefaultLayoutConfig()), selected)
+}
+
+func layoutWithDifficulty(base domain.LayoutConfig, selected string) (domain.LayoutConfig, domain.Difficulty, error) {
 	profile := domain.DefaultDifficultyProfile()
 	validator := domain.NewDifficultyValidator(profile.Default)
 
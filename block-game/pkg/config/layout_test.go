@@ -2,6 +2,7 @@ package config
 
 import (
 	"block-game/pkg/domain"
+	"block-game/pkg/level"
 	"testing"
 )
 
@@ -54,3 +55,21 @@ func TestLayoutWithDifficultyInvalidFallsBack(t *testing.T) {
 		t.Fatalf("config difficulty should be NORMAL on fallback")
 	}
 }
+
+func TestLayoutForLevelAppliesOverridesBeforeDifficulty(t *testing.T) {
+	lvl, err := level.Parse("test.json", []byte(`{"ball": {"speed": 200}, "grid": {"blockW": 70, "blockH": 30, "rows": ["N"]}}`))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	normal, _, err := LayoutForLevel(lvl, "NORMAL")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	hard, _, err := LayoutForLevel(lvl, "HARD")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if hard.BallSpeed <= normal.BallSpeed {
+		t.Fatalf("difficulty should scale the level's ball speed: normal %v hard %v", normal.BallSpeed, hard.BallSpeed)
+	}
+}
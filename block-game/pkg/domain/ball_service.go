@@ -2,105 +2,211 @@ package domain
 
 import "math"
 
+// maxSweepIterations は 1 ステップ内で 1 つのボールが解決する接触数の上限
+const maxSweepIterations = 16
+
 type Ball struct {
-	X, Y   float64
-	VX, VY float64
-	Radius float64
+	X, Y         float64
+	VX, VY       float64 // px/s
+	Radius       float64
+	Attached     bool    // パドルに乗って発射待ちの状態
+	AttachOffset float64 // Attached のときのパドル中心からの X オフセット
+	Caught       bool    // キャッチ効果でパドルに付いた（サーブ待ちではない）
+	ReleaseAngle float64 // Caught のときの発射角（ラジアン）。付いた位置で決まる
+	Piercing     bool    // ファイアボール中。壊せるブロックを反射せずに壊して進む
+	Speed        float64 // 効果を除いた速さ (px/s)。速度ベクトルの大きさは Speed × ModBallSpeed
+}
+
+// speed は効果を反映したボールの速さを返す
+func (b Ball) speed(scale float64) float64 {
+	return b.Speed * scale
+}
+
+// setSpeed は向きを保ったまま速度ベクトルの大きさを Speed × scale に合わせる。
+// 静止中（パドルに付いている）のボールはそのまま
+func (b *Ball) setSpeed(scale float64) {
+	v := reflectVelocity(b.VX, b.VY)
+	if v == 0 {
+		return
+	}
+	k := b.Speed * scale / v
+	b.VX *= k
+	b.VY *= k
+}
+
+// aim は速度ベクトルを角度 angle（上向きが正）、大きさ speed にする
+func (b *Ball) aim(angle, speed float64) {
+	b.VX = speed * math.Cos(angle)
+	b.VY = -speed * math.Sin(angle)
 }
 
 // BallService はボールの移動・衝突を扱うドメインサービス
-type BallService struct{}
+type BallService struct {
+	bruteForce bool // true なら空間インデックスを使わず全ブロックを走査する（比較用）
+}
 
 func NewBallService() BallService {
 	return BallService{}
 }
 
-// Advance は全ボールの移動と衝突処理を行う
-func (BallService) Advance(state *GameState, cfg LayoutConfig, rnd RandomSource) {
+// Advance は全ボールの移動と衝突処理を行う。
+// 1 ステップ分の移動を衝突時刻ごとに区切り、壁・パドル・ブロックとの接触を
+// 発生順に解決してから残りの移動を続けるため、高速でも薄い物体をすり抜けない。
+func (s BallService) Advance(state *GameState, cfg LayoutConfig, rnd RandomSource) {
 	if rnd == nil {
 		rnd = NewRandomSource(cfg.Seed)
 	}
 
 	newBalls := make([]Ball, 0, len(state.Balls))
 	for _, ball := range state.Balls {
-		ball.X += ball.VX
-		ball.Y += ball.VY
-
-		if ball.X-ball.Radius <= 0 || ball.X+ball.Radius >= cfg.ScreenW {
-			ball.VX = -ball.VX
-			// 壁の内側に押し戻すことで連続反射による滑りを防ぐ
-			if ball.X-ball.Radius < 0 {
-				ball.X = ball.Radius
-			} else if ball.X+ball.Radius > cfg.ScreenW {
-				ball.X = cfg.ScreenW - ball.Radius
-			}
+		if ball.Attached {
+			newBalls = append(newBalls, ball)
+			continue
 		}
+		s.sweep(state, cfg, rnd, &ball)
 
-		if ball.Y-ball.Radius <= 0 {
-			ball.VY = -ball.VY
-			// 上壁との衝突後に位置を補正
-			if ball.Y-ball.Radius < 0 {
-				ball.Y = ball.Radius
-			}
+		if state.Barrier.stops(ball) {
+			state.Barrier.bounce(state, &ball)
 		}
-
 		if ball.Y+ball.Radius > cfg.ScreenH {
+			state.Stats.BallsLost++
+			state.emit(BallLost{X: ball.X})
 			continue
 		}
+		newBalls = append(newBalls, ball)
+	}
+
+	state.Balls = newBalls
+}
+
+// sweep は 1 つのボールを 1 ステップ（cfg.StepSeconds() 秒）分移動させる
+func (s BallService) sweep(state *GameState, cfg LayoutConfig, rnd RandomSource, ball *Ball) {
+	remaining := cfg.StepSeconds()
+	var contacts []contact
+	var candidates []int
+	for i := 0; i < maxSweepIterations && remaining > 0; i++ {
+		dx := ball.VX * remaining
+		dy := ball.VY * remaining
+
+		candidates = s.candidateBlocks(state, cfg, ball, dx, dy, candidates[:0])
+		contacts = s.firstContacts(state, cfg, ball, dx, dy, candidates, contacts[:0])
+		if len(contacts) == 0 {
+			ball.X += dx
+			ball.Y += dy
+			return
+		}
 
-		if ball.Y+ball.Radius >= state.Paddle.Y &&
-			ball.Y-ball.Radius <= state.Paddle.Y+state.Paddle.Height &&
-			ball.X+ball.Radius >= state.Paddle.X &&
-			ball.X-ball.Radius <= state.Paddle.X+state.Paddle.Width {
-			hitPos := (ball.X - state.Paddle.X) / state.Paddle.Width
-			angle := math.Pi * (0.5 + hitPos*0.5)
-			speed := reflectVelocity(ball.VX, ball.VY)
-			ball.VX = speed * math.Cos(angle)
-			ball.VY = -speed * math.Sin(angle)
-			ball.Y = state.Paddle.Y - ball.Radius
+		t := contacts[0].time
+		ball.X += dx * t
+		ball.Y += dy * t
+		remaining *= 1 - t
+		for _, c := range contacts {
+			state.Contacts = append(state.Contacts, c.record(ball))
+			s.resolve(state, cfg, rnd, ball, c)
+		}
+		// キャッチされたボールは残りの移動をしない
+		if ball.Attached {
+			return
 		}
+	}
+}
 
+// candidateBlocks は移動 (dx, dy) の掃引範囲と重なりうるブロック添字を昇順で返す
+func (s BallService) candidateBlocks(state *GameState, cfg LayoutConfig, ball *Ball, dx, dy float64, buf []int) []int {
+	if s.bruteForce {
 		for i := range state.Blocks {
-			if !state.Blocks[i].Alive {
-				continue
-			}
-
-			block := &state.Blocks[i]
-			blockCenterX := block.X + cfg.BlockW/2
-			blockCenterY := block.Y + cfg.BlockH/2
-			blockHalfWidth := cfg.BlockW / 2
-			blockHalfHeight := cfg.BlockH / 2
-
-			dx := ball.X - blockCenterX
-			dy := ball.Y - blockCenterY
-
-			if math.Abs(dx) < blockHalfWidth+ball.Radius &&
-				math.Abs(dy) < blockHalfHeight+ball.Radius {
-				block.Alive = false
-				state.Score++
-				tryDropItem(state, cfg, block, rnd)
-
-				if math.Abs(dx/blockHalfWidth) > math.Abs(dy/blockHalfHeight) {
-					ball.VX = -ball.VX
-				} else {
-					ball.VY = -ball.VY
-				}
-
-				if dx > 0 {
-					ball.X = block.X + cfg.BlockW + ball.Radius
-				} else {
-					ball.X = block.X - ball.Radius
-				}
-				if dy > 0 {
-					ball.Y = block.Y + cfg.BlockH + ball.Radius
-				} else {
-					ball.Y = block.Y - ball.Radius
-				}
-			}
+			buf = append(buf, i)
 		}
+		return buf
+	}
+	minX := math.Min(ball.X, ball.X+dx) - ball.Radius
+	minY := math.Min(ball.Y, ball.Y+dy) - ball.Radius
+	w := math.Abs(dx) + 2*ball.Radius
+	h := math.Abs(dy) + 2*ball.Radius
+	return state.BlockGrid(cfg).Query(minX, minY, w, h, buf)
+}
 
-		newBalls = append(newBalls, ball)
+// firstContacts は移動 (dx, dy) の間に最も早く起こる接触を、同時に起こるものも含めて返す。
+// 並びは壁・パドル・ブロック（添字順）の順で、解決もこの順に行う。
+func (BallService) firstContacts(state *GameState, cfg LayoutConfig, ball *Ball, dx, dy float64, candidates []int, buf []contact) []contact {
+	best := math.Inf(1)
+	consider := func(c contact) {
+		switch {
+		case c.time < best-contactTimeEpsilon:
+			best = c.time
+			buf = append(buf[:0], c)
+		case c.time <= best+contactTimeEpsilon:
+			buf = append(buf, c)
+		}
 	}
 
-	state.Balls = newBalls
+	if c, ok := sweepWalls(ball.X, ball.Y, dx, dy, ball.Radius, cfg.ScreenW); ok {
+		consider(c)
+	}
+
+	// パドルは下向きに進むボールのみ跳ね返す
+	if ball.VY > 0 {
+		p := state.Paddle
+		if c, ok := sweepCircleRect(ball.X, ball.Y, dx, dy, ball.Radius, p.X, p.Y, p.Width, p.Height); ok {
+			c.surface = surfacePaddle
+			consider(c)
+		}
+	}
+
+	for _, i := range candidates {
+		block := &state.Blocks[i]
+		if !block.Alive {
+			continue
+		}
+		if c, ok := sweepCircleBlock(ball.X, ball.Y, dx, dy, ball.Radius, *block); ok {
+			c.surface = surfaceBlock
+			c.block = i
+			consider(c)
+		}
+	}
+	return buf
+}
+
+// paddleBounceAngle はパドル上の X 位置で決まる反射角を返す
+func paddleBounceAngle(paddle Paddle, x float64) float64 {
+	hitPos := (x - paddle.X) / paddle.Width
+	return math.Pi * (0.5 + hitPos*0.5)
+}
+
+// resolve は接触に応じて速度・位置・ゲーム状態を更新する。
+// 同時接触では接触ごとに 1 回だけ処理し、反射はまだその面へ向かっている場合にのみ行う。
+// そのため隣り合うブロックの継ぎ目では 1 回だけ、内角では両軸が 1 回ずつ反転する。
+func (BallService) resolve(state *GameState, cfg LayoutConfig, rnd RandomSource, ball *Ball, c contact) {
+	approaching := ball.VX*c.nx+ball.VY*c.ny < 0
+
+	switch c.surface {
+	case surfaceWall:
+		if approaching {
+			ball.VX, ball.VY = bounce(ball.VX, ball.VY, c.nx, c.ny)
+		}
+		// 壁の内側に押し戻すことで連続反射による滑りを防ぐ
+		ball.X = math.Min(math.Max(ball.X, ball.Radius), cfg.ScreenW-ball.Radius)
+		ball.Y = math.Max(ball.Y, ball.Radius)
+	case surfacePaddle:
+		if ball.VY <= 0 {
+			return
+		}
+		angle := paddleBounceAngle(state.Paddle, ball.X)
+		if _, ok := state.Effect(EffectCatch); ok {
+			catchBall(state, ball, angle)
+			return
+		}
+		ball.aim(angle, ball.speed(state.Modifier(ModBallSpeed)))
+		ball.Y = state.Paddle.Y - ball.Radius
+	case surfaceBlock:
+		// 貫通中は壊せるブロックを一撃で壊し、反射せずにそのまま進む
+		if ball.Piercing && state.Blocks[c.block].Destructible() {
+			destroyBlock(state, cfg, c.block, rnd)
+			return
+		}
+		hitBlock(state, cfg, c.block, rnd)
+		if approaching {
+			ball.VX, ball.VY = bounce(ball.VX, ball.VY, c.nx, c.ny)
+		}
+	}
 }
@@ -1,10 +1,13 @@
 package domain
 
-import "testing"
+import (
+	"math"
+	"testing"
+)
 
 func TestBallService_BounceAndClampWall(t *testing.T) {
 	cfg := baseLayout()
-	state := NewGameState(cfg, []Block{})
+	state := newLaunchedState(cfg, []Block{})
 	svc := NewBallService()
 
 	// 左壁へ向かうよう設定し、反射と位置補正を検証
@@ -26,8 +29,8 @@ func TestBallService_BounceAndClampWall(t *testing.T) {
 
 func TestBallService_DestroysBlock(t *testing.T) {
 	cfg := baseLayout()
-	block := Block{X: 100, Y: 100, Alive: true}
-	state := NewGameState(cfg, []Block{block})
+	block := Block{X: 100, Y: 100, W: 70, H: 30, Alive: true}
+	state := newLaunchedState(cfg, []Block{block})
 	svc := NewBallService()
 
 	b := &state.Balls[0]
@@ -49,9 +52,54 @@ func TestBallService_DestroysBlock(t *testing.T) {
 	}
 }
 
+func TestBallService_PiercingBallPassesThroughBlocks(t *testing.T) {
+	cfg := baseLayout()
+	cfg.Drops.Chance = 0
+	tests := []struct {
+		name      string
+		blocks    []Block
+		wantAlive []bool
+		wantUp    bool
+	}{
+		{
+			name:      "destructible blocks break without reflection",
+			blocks:    []Block{NewBlock(100, 255, 70, 30, BlockTypeHard), NewBlock(100, 220, 70, 30, BlockTypeNormal)},
+			wantAlive: []bool{false, false},
+			wantUp:    true,
+		},
+		{
+			name:      "indestructible blocks still reflect",
+			blocks:    []Block{NewBlock(100, 255, 70, 30, BlockTypeIndestructible), NewBlock(200, 100, 70, 30, BlockTypeNormal)},
+			wantAlive: []bool{true, true},
+			wantUp:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := newLaunchedState(cfg, tt.blocks)
+			// 1 ステップで 50px 進む速さで、真上の 2 ブロックにまとめて届く
+			b := &state.Balls[0]
+			b.X, b.Y = 135, 300
+			b.VX, b.VY = 0, -10*cfg.BallSpeed
+			b.Piercing = true
+
+			NewBallService().Advance(state, cfg, NewRandomSource(nil))
+
+			for i, want := range tt.wantAlive {
+				if state.Blocks[i].Alive != want {
+					t.Fatalf("block %d: expected alive=%v", i, want)
+				}
+			}
+			if up := state.Balls[0].VY < 0; up != tt.wantUp {
+				t.Fatalf("expected moving up=%v, got VY=%f", tt.wantUp, state.Balls[0].VY)
+			}
+		})
+	}
+}
+
 func TestBallService_RemovesFallenBall(t *testing.T) {
 	cfg := baseLayout()
-	state := NewGameState(cfg, []Block{})
+	state := newLaunchedState(cfg, []Block{})
 	svc := NewBallService()
 
 	b := &state.Balls[0]
@@ -64,3 +112,131 @@ func TestBallService_RemovesFallenBall(t *testing.T) {
 		t.Fatalf("expected fallen ball to be removed, got %d balls", len(state.Balls))
 	}
 }
+
+func TestBallService_NoTunnelingThroughThinBlockAt10x(t *testing.T) {
+	cfg := baseLayout()
+	cfg.BlockH = 4
+	block := Block{X: 100, Y: 100, W: 70, H: 4, Alive: true}
+	state := newLaunchedState(cfg, []Block{block})
+	svc := NewBallService()
+
+	// 1 tick でブロックを完全に飛び越える速度（旧実装ではすり抜けていた）
+	b := &state.Balls[0]
+	b.X = block.X + block.W/2
+	b.Y = block.Y + block.H + b.Radius + 5
+	b.VX = 0
+	b.VY = -cfg.BallSpeed * 10
+
+	svc.Advance(state, cfg, NewRandomSource(nil))
+
+	if state.Blocks[0].Alive {
+		t.Fatalf("expected fast ball to hit the thin block")
+	}
+	if state.Balls[0].VY <= 0 {
+		t.Fatalf("expected VY to invert after collision, got %f", state.Balls[0].VY)
+	}
+	if state.Balls[0].Y-state.Balls[0].Radius < block.Y+block.H {
+		t.Fatalf("expected ball to stay below the block, got Y=%f", state.Balls[0].Y)
+	}
+}
+
+func TestBallService_NoTunnelingThroughPaddleAt10x(t *testing.T) {
+	cfg := baseLayout()
+	state := newLaunchedState(cfg, []Block{})
+	svc := NewBallService()
+
+	b := &state.Balls[0]
+	b.X = state.Paddle.X + state.Paddle.Width/2
+	b.Y = state.Paddle.Y - b.Radius - 10
+	b.VX = 0
+	b.VY = cfg.BallSpeed * 10
+
+	svc.Advance(state, cfg, NewRandomSource(nil))
+
+	if len(state.Balls) != 1 {
+		t.Fatalf("expected ball to be caught by the paddle, got %d balls", len(state.Balls))
+	}
+	if state.Balls[0].VY >= 0 {
+		t.Fatalf("expected ball to bounce upward, got VY=%f", state.Balls[0].VY)
+	}
+	if state.Balls[0].Y > state.Paddle.Y-state.Balls[0].Radius {
+		t.Fatalf("expected ball above the paddle, got Y=%f", state.Balls[0].Y)
+	}
+}
+
+func TestBallService_ResolvesContactsInOrderAt10x(t *testing.T) {
+	cfg := baseLayout()
+	near := Block{X: 100, Y: 200, W: 70, H: 30, Alive: true}
+	far := Block{X: 100, Y: 100, W: 70, H: 30, Alive: true}
+	state := newLaunchedState(cfg, []Block{far, near})
+	svc := NewBallService()
+
+	// 奥のブロックが先に列挙されていても、手前のブロックに先に当たる
+	b := &state.Balls[0]
+	b.X = near.X + cfg.BlockW/2
+	b.Y = near.Y + cfg.BlockH + b.Radius + 1
+	b.VX = 0
+	b.VY = -cfg.BallSpeed * 10
+
+	svc.Advance(state, cfg, NewRandomSource(nil))
+
+	if state.Blocks[1].Alive {
+		t.Fatalf("expected the nearer block to be destroyed")
+	}
+	if !state.Blocks[0].Alive {
+		t.Fatalf("expected the farther block to survive")
+	}
+	if state.Score != 1 {
+		t.Fatalf("expected exactly one hit, got score %d", state.Score)
+	}
+}
+
+func TestBallService_WallBounceKeepsRemainingMovement(t *testing.T) {
+	cfg := baseLayout()
+	state := newLaunchedState(cfg, []Block{})
+	svc := NewBallService()
+
+	b := &state.Balls[0]
+	b.X = b.Radius + 10
+	b.Y = cfg.ScreenH / 2
+	b.VX = -cfg.BallSpeed * 10
+	b.VY = 0
+
+	svc.Advance(state, cfg, NewRandomSource(nil))
+
+	// 10px で壁に到達し、残り 40px を反射方向へ進む
+	want := b.Radius + 40
+	if math.Abs(state.Balls[0].X-want) > 1e-9 {
+		t.Fatalf("expected X=%f after bounce, got %f", want, state.Balls[0].X)
+	}
+}
+
+func TestAdvance_RecordsContactsOfLatestStep(t *testing.T) {
+	cfg := baseLayout()
+	block := Block{X: 100, Y: 100, W: 70, H: 30, Alive: true, HP: 2, MaxHP: 2}
+	state := newLaunchedState(cfg, []Block{block})
+
+	b := &state.Balls[0]
+	b.X = block.X + block.W/2
+	b.Y = block.Y + block.H + b.Radius + 1
+	b.VX = 0
+	b.VY = -cfg.BallSpeed
+
+	Advance(state, InputState{}, cfg, NewRandomSource(nil))
+
+	if len(state.Contacts) != 1 {
+		t.Fatalf("expected one contact, got %+v", state.Contacts)
+	}
+	c := state.Contacts[0]
+	if c.Block != 0 || c.NY != 1 || c.NX != 0 {
+		t.Fatalf("expected the block's bottom face, got %+v", c)
+	}
+	if math.Abs(c.Y-(block.Y+block.H+b.Radius)) > 1e-6 {
+		t.Fatalf("contact should be where the ball touched, got y=%f", c.Y)
+	}
+
+	Advance(state, InputState{}, cfg, NewRandomSource(nil))
+	if len(state.Contacts) != 0 {
+		t.Fatalf("contacts should only cover the latest step, got %+v", state.Contacts)
+	}
+}
@@ -0,0 +1,31 @@
+package domain
+
+// Barrier is a floor below the paddle that saves one ball and then disappears.
+type Barrier struct {
+	Active bool
+	Y      float64 // top edge
+	Height float64
+}
+
+// raiseBarrier puts the barrier up at the bottom of the screen. Collecting another
+// while it is up changes nothing; it still bounces a single ball.
+func raiseBarrier(state *GameState, cfg LayoutConfig) {
+	state.Barrier = Barrier{
+		Active: true,
+		Y:      cfg.ScreenH - cfg.BarrierHeight,
+		Height: cfg.BarrierHeight,
+	}
+}
+
+// stops reports whether ball is falling through the barrier.
+func (b Barrier) stops(ball Ball) bool {
+	return b.Active && ball.VY > 0 && ball.Y+ball.Radius > b.Y
+}
+
+// bounce sends ball back up from the barrier's top edge and takes the barrier down.
+func (b *Barrier) bounce(state *GameState, ball *Ball) {
+	ball.Y = b.Y - ball.Radius
+	ball.VY = -ball.VY
+	b.Active = false
+	state.emit(BarrierHit{X: ball.X})
+}
@@ -0,0 +1,46 @@
+package domain
+
+import "testing"
+
+func TestBarrierSavesOneBall(t *testing.T) {
+	cfg := baseLayout()
+	cfg.BarrierHeight = 6
+	state := newLaunchedState(cfg, []Block{NewBlock(100, 100, 70, 30, BlockTypeNormal)})
+	raiseBarrier(state, cfg)
+	ball := &state.Balls[0]
+	ball.X, ball.Y = 400, cfg.ScreenH-ball.Radius-7
+	ball.VX, ball.VY = 0, cfg.BallSpeed
+
+	Advance(state, InputState{}, cfg, NewRandomSource(nil))
+
+	if len(state.Balls) != 1 || state.Balls[0].VY >= 0 {
+		t.Fatalf("expected the ball to bounce back up, got %+v", state.Balls)
+	}
+	if got, want := state.Balls[0].Y, cfg.ScreenH-cfg.BarrierHeight-ball.Radius; got != want {
+		t.Fatalf("expected the ball on top of the barrier at y=%v, got %v", want, got)
+	}
+	if state.Barrier.Active {
+		t.Fatal("expected the barrier to disappear after one bounce")
+	}
+	assertEvents(t, state.Events, []Event{BarrierHit{X: 400}})
+
+	state.Balls[0].Y, state.Balls[0].VY = cfg.ScreenH-ball.Radius, cfg.BallSpeed
+	Advance(state, InputState{}, cfg, NewRandomSource(nil))
+	if !state.GameOver {
+		t.Fatalf("expected the next ball to be lost, got %+v", state.Balls)
+	}
+}
+
+func TestBarrierIgnoresRisingBalls(t *testing.T) {
+	cfg := baseLayout()
+	cfg.BarrierHeight = 6
+	state := newLaunchedState(cfg, nil)
+	raiseBarrier(state, cfg)
+
+	if state.Barrier.stops(Ball{Y: cfg.ScreenH - 2, Radius: 5, VY: -cfg.BallSpeed}) {
+		t.Fatal("a rising ball should pass the barrier")
+	}
+	if !state.Barrier.stops(Ball{Y: cfg.ScreenH - 2, Radius: 5, VY: cfg.BallSpeed}) {
+		t.Fatal("a falling ball should hit the barrier")
+	}
+}
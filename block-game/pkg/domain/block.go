@@ -0,0 +1,227 @@
+package domain
+
+import "math"
+
+// BlockShape is the collision and drawing shape of a block within its bounds.
+type BlockShape int
+
+const (
+	BlockShapeRect        BlockShape = iota
+	BlockShapeCircle                 // circle inscribed in the W x H bounds (bumper)
+	BlockShapeRotatedRect            // W x H rectangle rotated by Angle around its centre
+)
+
+// BlockType represents how a block reacts to hits.
+type BlockType int
+
+const (
+	BlockTypeNormal         BlockType = iota
+	BlockTypeHard                     // needs several hits
+	BlockTypeIndestructible           // never breaks; ignored by win detection
+	BlockTypeExplosive                // destroys neighbouring blocks when it breaks
+	BlockTypeItem                     // always drops an item when it breaks (within the item limits)
+)
+
+// blockTypeOrder fixes the iteration order for weighted selection.
+var blockTypeOrder = []BlockType{
+	BlockTypeNormal,
+	BlockTypeHard,
+	BlockTypeIndestructible,
+	BlockTypeExplosive,
+	BlockTypeItem,
+}
+
+// BlockTypeSpec describes the durability, value and drops of a block type.
+type BlockTypeSpec struct {
+	HP        int  // hits needed to destroy; 0 means indestructible
+	Points    int  // score awarded when destroyed
+	ForceDrop bool // drops an item whatever the drop table's chance
+}
+
+var blockTypeSpecs = map[BlockType]BlockTypeSpec{
+	BlockTypeNormal:         {HP: 1, Points: 1},
+	BlockTypeHard:           {HP: 3, Points: 3},
+	BlockTypeIndestructible: {HP: 0, Points: 0},
+	BlockTypeExplosive:      {HP: 1, Points: 2},
+	BlockTypeItem:           {HP: 1, Points: 2, ForceDrop: true},
+}
+
+// BlockSpec returns the spec of the given block type (normal for unknown types).
+func BlockSpec(t BlockType) BlockTypeSpec {
+	if spec, ok := blockTypeSpecs[t]; ok {
+		return spec
+	}
+	return blockTypeSpecs[BlockTypeNormal]
+}
+
+type Block struct {
+	X, Y  float64 // top-left corner of the unrotated bounds
+	W, H  float64
+	Shape BlockShape
+	Angle float64 // rotation in radians (BlockShapeRotatedRect only)
+	Alive bool
+	Type  BlockType
+	HP    int // remaining hits; 0 on a destructible block counts as 1
+	MaxHP int
+}
+
+// NewBlock creates an alive rectangular block of the given type at full durability.
+func NewBlock(x, y, w, h float64, t BlockType) Block {
+	hp := BlockSpec(t).HP
+	return Block{X: x, Y: y, W: w, H: h, Alive: true, Type: t, HP: hp, MaxHP: hp}
+}
+
+// Center returns the centre of the block.
+func (b Block) Center() (float64, float64) {
+	return b.X + b.W/2, b.Y + b.H/2
+}
+
+// Bounds returns the axis-aligned bounding box of the block, including rotation.
+func (b Block) Bounds() (x, y, w, h float64) {
+	if b.Shape != BlockShapeRotatedRect || b.Angle == 0 {
+		return b.X, b.Y, b.W, b.H
+	}
+	sin, cos := math.Sincos(b.Angle)
+	w = math.Abs(b.W*cos) + math.Abs(b.H*sin)
+	h = math.Abs(b.W*sin) + math.Abs(b.H*cos)
+	cx, cy := b.Center()
+	return cx - w/2, cy - h/2, w, h
+}
+
+// overlaps reports whether the bounding boxes of two blocks overlap.
+func (b Block) overlaps(o Block) bool {
+	ax, ay, aw, ah := b.Bounds()
+	bx, by, bw, bh := o.Bounds()
+	return rectsOverlap(ax, ay, aw, ah, bx, by, bw, bh)
+}
+
+// ScaleBlocks resizes every block by scale around its centre,
+// e.g. to apply DifficultySetting.BlockSizeScale to blocks from level data.
+func ScaleBlocks(blocks []Block, scale float64) {
+	if scale <= 0 || scale == 1 {
+		return
+	}
+	for i := range blocks {
+		b := &blocks[i]
+		cx, cy := b.Center()
+		b.W *= scale
+		b.H *= scale
+		b.X = cx - b.W/2
+		b.Y = cy - b.H/2
+	}
+}
+
+// Destructible reports whether the block can be destroyed by hits.
+func (b Block) Destructible() bool {
+	return b.Type != BlockTypeIndestructible
+}
+
+// BlocksCleared reports whether every destructible block has been destroyed.
+// Indestructible blocks are ignored; a field without blocks is never cleared.
+func (s *GameState) BlocksCleared() bool {
+	if len(s.Blocks) == 0 {
+		return false
+	}
+	for _, block := range s.Blocks {
+		if block.Alive && block.Destructible() {
+			return false
+		}
+	}
+	return true
+}
+
+// hitBlock applies one hit to block i, destroying it once its HP runs out.
+func hitBlock(state *GameState, cfg LayoutConfig, i int, rnd RandomSource) {
+	block := &state.Blocks[i]
+	if !block.Alive || !block.Destructible() {
+		return
+	}
+	if block.HP > 1 {
+		block.HP--
+		state.emit(BlockHit{Block: i, HP: block.HP})
+		return
+	}
+	destroyBlock(state, cfg, i, rnd)
+}
+
+// destroyBlock removes block i, scores it and resolves its on-destroy behaviour.
+// Explosions propagate breadth-first so chained explosive blocks are handled iteratively.
+func destroyBlock(state *GameState, cfg LayoutConfig, i int, rnd RandomSource) {
+	queue := []int{i}
+	var neighbours []int
+	for len(queue) > 0 {
+		idx := queue[0]
+		queue = queue[1:]
+		block := &state.Blocks[idx]
+		if !block.Alive || !block.Destructible() {
+			continue
+		}
+
+		block.HP = 0
+		state.DestroyBlock(idx, cfg)
+		state.Stats.BlocksDestroyed++
+		cx, cy := block.Center()
+		state.emit(BlockDestroyed{Block: idx, Type: block.Type, X: cx, Y: cy})
+		state.AddScore(BlockSpec(block.Type).Points)
+
+		dropItem(state, cfg, block, rnd)
+		if block.Type == BlockTypeExplosive {
+			// 爆発範囲は自身の周囲に半ブロック分
+			bx, by, bw, bh := block.Bounds()
+			reach := Block{X: bx - bw/2, Y: by - bh/2, W: bw * 2, H: bh * 2}
+			neighbours = state.BlockGrid(cfg).Query(reach.X, reach.Y, reach.W, reach.H, neighbours[:0])
+			for _, n := range neighbours {
+				if reach.overlaps(state.Blocks[n]) {
+					queue = append(queue, n)
+				}
+			}
+		}
+	}
+}
+
+// pickBlockType draws a block type using cfg.BlockTypeWeights.
+// Without any non-normal weight it returns BlockTypeNormal without consuming randomness.
+func pickBlockType(cfg LayoutConfig, rnd RandomSource) BlockType {
+	total := 0.0
+	mixed := false
+	for _, t := range blockTypeOrder {
+		w := cfg.BlockTypeWeights[t]
+		if w <= 0 {
+			continue
+		}
+		total += w
+		if t != BlockTypeNormal {
+			mixed = true
+		}
+	}
+	if !mixed {
+		return BlockTypeNormal
+	}
+
+	r := rnd.Float64() * total
+	for _, t := range blockTypeOrder {
+		w := cfg.BlockTypeWeights[t]
+		if w <= 0 {
+			continue
+		}
+		if r < w {
+			return t
+		}
+		r -= w
+	}
+	return blockTypeOrder[len(blockTypeOrder)-1]
+}
+
+// ensureDestructible turns the first block into a normal one when the weights left
+// none destructible, so that a generated stage can always be cleared.
+func ensureDestructible(blocks []Block) {
+	for _, b := range blocks {
+		if b.Destructible() {
+			return
+		}
+	}
+	if len(blocks) > 0 {
+		b := blocks[0]
+		blocks[0] = NewBlock(b.X, b.Y, b.W, b.H, BlockTypeNormal)
+	}
+}
@@ -0,0 +1,132 @@
+package domain
+
+import (
+	"math"
+	"slices"
+)
+
+// BlockGrid is a uniform-grid broad phase over GameState.Blocks.
+// Each alive block is registered in every cell its bounds touch, so a query
+// only visits blocks near the queried area instead of the whole slice.
+type BlockGrid struct {
+	cellW, cellH float64
+	cols, rows   int
+	cells        [][]int
+	stamps       []uint32 // per-block marker used to de-duplicate query results
+	stamp        uint32
+	base         *Block // first element of the indexed slice, to detect replacement
+}
+
+// NewBlockGrid indexes the alive blocks by their bounds, using cells of the typical
+// generated block size (cfg.BlockW x cfg.BlockH). Larger blocks simply span more cells.
+func NewBlockGrid(blocks []Block, cfg LayoutConfig) *BlockGrid {
+	cellW := math.Max(cfg.BlockW, 1)
+	cellH := math.Max(cfg.BlockH, 1)
+	cols := int(math.Ceil(cfg.ScreenW/cellW)) + 1
+	rows := int(math.Ceil(cfg.ScreenH/cellH)) + 1
+	g := &BlockGrid{
+		cellW:  cellW,
+		cellH:  cellH,
+		cols:   cols,
+		rows:   rows,
+		cells:  make([][]int, cols*rows),
+		stamps: make([]uint32, len(blocks)),
+	}
+	if len(blocks) > 0 {
+		g.base = &blocks[0]
+	}
+	for i, b := range blocks {
+		if b.Alive {
+			g.insert(i, b)
+		}
+	}
+	return g
+}
+
+// indexes reports whether the grid was built for this exact blocks slice and layout.
+func (g *BlockGrid) indexes(blocks []Block, cfg LayoutConfig) bool {
+	if len(g.stamps) != len(blocks) || g.cellW != math.Max(cfg.BlockW, 1) || g.cellH != math.Max(cfg.BlockH, 1) {
+		return false
+	}
+	return len(blocks) == 0 || g.base == &blocks[0]
+}
+
+// Remove unregisters block i, e.g. when it is destroyed.
+func (g *BlockGrid) Remove(i int, b Block) {
+	x, y, w, h := b.Bounds()
+	g.forCells(x, y, w, h, func(cell int) {
+		list := g.cells[cell]
+		for k, idx := range list {
+			if idx == i {
+				g.cells[cell] = append(list[:k], list[k+1:]...)
+				return
+			}
+		}
+	})
+}
+
+// Move re-registers block i after its bounds changed from old to moved.
+func (g *BlockGrid) Move(i int, old, moved Block) {
+	g.Remove(i, old)
+	if moved.Alive {
+		g.insert(i, moved)
+	}
+}
+
+// Query appends to buf the indices of blocks whose cells overlap the given rectangle,
+// in ascending order and without duplicates. Callers still run the exact test.
+func (g *BlockGrid) Query(x, y, w, h float64, buf []int) []int {
+	g.stamp++
+	if g.stamp == 0 {
+		// wrapped around: clear stale markers
+		for i := range g.stamps {
+			g.stamps[i] = 0
+		}
+		g.stamp = 1
+	}
+	start := len(buf)
+	g.forCells(x, y, w, h, func(cell int) {
+		for _, idx := range g.cells[cell] {
+			if g.stamps[idx] != g.stamp {
+				g.stamps[idx] = g.stamp
+				buf = append(buf, idx)
+			}
+		}
+	})
+	slices.Sort(buf[start:])
+	return buf
+}
+
+func (g *BlockGrid) insert(i int, b Block) {
+	x, y, w, h := b.Bounds()
+	g.forCells(x, y, w, h, func(cell int) {
+		g.cells[cell] = append(g.cells[cell], i)
+	})
+}
+
+// forCells calls fn for every cell touched by the rectangle, clamped to the grid.
+func (g *BlockGrid) forCells(x, y, w, h float64, fn func(cell int)) {
+	c0, r0 := g.cellOf(x, y)
+	c1, r1 := g.cellOf(x+w, y+h)
+	for r := r0; r <= r1; r++ {
+		for c := c0; c <= c1; c++ {
+			fn(r*g.cols + c)
+		}
+	}
+}
+
+func (g *BlockGrid) cellOf(x, y float64) (int, int) {
+	c := int(math.Floor(x / g.cellW))
+	r := int(math.Floor(y / g.cellH))
+	return clampInt(c, 0, g.cols-1), clampInt(r, 0, g.rows-1)
+}
+
+func clampInt(v, lo, hi int) int {
+	if v < lo {
+		return lo
+	}
+	if v > hi {
+		return hi
+	}
+	return v
+}
@@ -0,0 +1,154 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestBlockGridQueryReturnsNearbyBlocks(t *testing.T) {
+	cfg := baseLayout()
+	blocks := []Block{
+		{X: 0, Y: 0, W: 70, H: 30, Alive: true},
+		{X: 400, Y: 300, W: 70, H: 30, Alive: true},
+		{X: 700, Y: 500, W: 70, H: 30, Alive: true},
+		{X: 420, Y: 310, W: 70, H: 30, Alive: false},
+	}
+	grid := NewBlockGrid(blocks, cfg)
+
+	got := grid.Query(390, 290, 20, 20, nil)
+	if !reflect.DeepEqual(got, []int{1}) {
+		t.Fatalf("expected only block 1, got %v", got)
+	}
+
+	got = grid.Query(0, 0, cfg.ScreenW, cfg.ScreenH, nil)
+	if !reflect.DeepEqual(got, []int{0, 1, 2}) {
+		t.Fatalf("expected all alive blocks in order, got %v", got)
+	}
+}
+
+func TestBlockGridRemoveAndMove(t *testing.T) {
+	cfg := baseLayout()
+	state := NewGameState(cfg, []Block{
+		{X: 100, Y: 100, W: 70, H: 30, Alive: true},
+		{X: 300, Y: 100, W: 70, H: 30, Alive: true},
+	})
+
+	state.DestroyBlock(0, cfg)
+	if got := state.BlockGrid(cfg).Query(100, 100, 10, 10, nil); len(got) != 0 {
+		t.Fatalf("expected destroyed block to leave the index, got %v", got)
+	}
+
+	state.MoveBlock(1, 500, 200, cfg)
+	if got := state.BlockGrid(cfg).Query(300, 100, 10, 10, nil); len(got) != 0 {
+		t.Fatalf("expected moved block to leave its old cells, got %v", got)
+	}
+	if got := state.BlockGrid(cfg).Query(510, 210, 10, 10, nil); !reflect.DeepEqual(got, []int{1}) {
+		t.Fatalf("expected moved block at new cells, got %v", got)
+	}
+}
+
+func TestBlockGridRebuildsWhenBlocksReplaced(t *testing.T) {
+	cfg := baseLayout()
+	state := NewGameState(cfg, []Block{{X: 100, Y: 100, W: 70, H: 30, Alive: true}})
+	first := state.BlockGrid(cfg)
+
+	state.Blocks = []Block{{X: 600, Y: 400, W: 70, H: 30, Alive: true}}
+	if state.BlockGrid(cfg) == first {
+		t.Fatalf("expected index to be rebuilt for a new blocks slice")
+	}
+	if got := state.BlockGrid(cfg).Query(600, 400, 1, 1, nil); !reflect.DeepEqual(got, []int{0}) {
+		t.Fatalf("expected new block to be indexed, got %v", got)
+	}
+}
+
+// 空間インデックス経由でも全走査と同じ結果になることを確認する
+func TestBallServiceGridMatchesBruteForce(t *testing.T) {
+	cfg := baseLayout()
+	cfg.Drops.Chance = 0
+	blocks := GenerateGridFallback(cfg, nil)
+
+	run := func(svc BallService) *GameState {
+		state := NewGameState(cfg, append([]Block(nil), blocks...))
+		state.Balls = benchBalls(cfg, 8)
+		for i := 0; i < 600; i++ {
+			svc.Advance(state, cfg, NewRandomSource(nil))
+		}
+		return state
+	}
+
+	grid := run(NewBallService())
+	brute := run(BallService{bruteForce: true})
+	if !reflect.DeepEqual(grid.Balls, brute.Balls) || !reflect.DeepEqual(grid.Blocks, brute.Blocks) || grid.Score != brute.Score {
+		t.Fatalf("grid and brute-force results differ: score %d vs %d", grid.Score, brute.Score)
+	}
+}
+
+func benchBalls(cfg LayoutConfig, n int) []Ball {
+	balls := make([]Ball, n)
+	for i := range balls {
+		angle := math.Pi/6 + float64(i)*math.Pi/(3*float64(n))
+		balls[i] = Ball{
+			X:      cfg.ScreenW * float64(i+1) / float64(n+1),
+			Y:      cfg.PaddleY - 40,
+			VX:     cfg.BallSpeed * math.Cos(angle),
+			VY:     -cfg.BallSpeed * math.Sin(angle),
+			Radius: cfg.BallRadius,
+		}
+	}
+	return balls
+}
+
+// benchLayout は count 個のブロックを画面上部に敷き詰めたレイアウトを返す
+func benchLayout(count int) (LayoutConfig, []Block) {
+	cfg := baseLayout()
+	cfg.Drops.Chance = 0
+	cols := int(math.Ceil(math.Sqrt(float64(count) * 2)))
+	rows := (count + cols - 1) / cols
+	cfg.BlockW = cfg.ScreenW / float64(cols)
+	cfg.BlockH = (cfg.PaddleY - cfg.MinPaddleGap) / float64(rows)
+
+	blocks := make([]Block, 0, count)
+	for i := 0; i < count; i++ {
+		blocks = append(blocks, NewBlock(float64(i%cols)*cfg.BlockW, float64(i/cols)*cfg.BlockH, cfg.BlockW, cfg.BlockH, BlockTypeNormal))
+	}
+	return cfg, blocks
+}
+
+func BenchmarkBallServiceAdvance(b *testing.B) {
+	for _, count := range []int{50, 500, 5000} {
+		for _, mode := range []struct {
+			name string
+			svc  BallService
+		}{
+			{"naive", BallService{bruteForce: true}},
+			{"grid", NewBallService()},
+		} {
+			b.Run(fmt.Sprintf("%s/%d", mode.name, count), func(b *testing.B) {
+				cfg, blocks := benchLayout(count)
+				state := NewGameState(cfg, blocks)
+				balls := benchBalls(cfg, cfg.MaxBalls)
+				rnd := NewRandomSource(nil)
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					// ボールはブロック帯の下を往復させ、ブロック数を一定に保つ
+					state.Balls = append(state.Balls[:0], balls...)
+					mode.svc.Advance(state, cfg, rnd)
+				}
+			})
+		}
+	}
+}
+
+func TestBlockGridIndexesLargeBlocksInEveryCell(t *testing.T) {
+	cfg := baseLayout()
+	wide := Block{X: 0, Y: 100, W: cfg.ScreenW, H: 10, Alive: true}
+	grid := NewBlockGrid([]Block{wide}, cfg)
+
+	for _, x := range []float64{5, 400, 790} {
+		if got := grid.Query(x, 105, 1, 1, nil); !reflect.DeepEqual(got, []int{0}) {
+			t.Fatalf("expected wide block at x=%f, got %v", x, got)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package domain
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHitBlockHardNeedsSeveralHits(t *testing.T) {
+	cfg := baseLayout()
+	cfg.Drops.Chance = 0
+	state := NewGameState(cfg, []Block{NewBlock(100, 100, 70, 30, BlockTypeHard)})
+	rnd := NewRandomSource(nil)
+
+	for i := 0; i < BlockSpec(BlockTypeHard).HP-1; i++ {
+		hitBlock(state, cfg, 0, rnd)
+		if !state.Blocks[0].Alive {
+			t.Fatalf("hard block broke after %d hits", i+1)
+		}
+	}
+	if state.Score != 0 {
+		t.Fatalf("expected no score before the block breaks, got %d", state.Score)
+	}
+
+	hitBlock(state, cfg, 0, rnd)
+	if state.Blocks[0].Alive {
+		t.Fatalf("expected hard block to break on its last hit")
+	}
+	if state.Score != BlockSpec(BlockTypeHard).Points {
+		t.Fatalf("expected %d points, got %d", BlockSpec(BlockTypeHard).Points, state.Score)
+	}
+}
+
+func TestIndestructibleBlockIgnoredByWinDetection(t *testing.T) {
+	cfg := baseLayout()
+	cfg.Drops.Chance = 0
+	state := NewGameState(cfg, []Block{
+		NewBlock(100, 100, 70, 30, BlockTypeIndestructible),
+		NewBlock(300, 100, 70, 30, BlockTypeNormal),
+	})
+	rnd := NewRandomSource(nil)
+
+	hitBlock(state, cfg, 0, rnd)
+	if !state.Blocks[0].Alive {
+		t.Fatalf("expected indestructible block to survive")
+	}
+	if state.BlocksCleared() {
+		t.Fatalf("expected field not cleared while a normal block is alive")
+	}
+
+	hitBlock(state, cfg, 1, rnd)
+	if !state.BlocksCleared() {
+		t.Fatalf("expected field cleared with only indestructible blocks left")
+	}
+}
+
+func TestExplosiveBlockChainsToNeighbours(t *testing.T) {
+	cfg := baseLayout()
+	cfg.Drops.Chance = 0
+	state := NewGameState(cfg, []Block{
+		NewBlock(100, 100, 70, 30, BlockTypeExplosive),
+		NewBlock(175, 100, 70, 30, BlockTypeExplosive), // 隣接: 連鎖する
+		NewBlock(250, 100, 70, 30, BlockTypeHard),      // 連鎖先の隣接: HP に関係なく壊れる
+		NewBlock(325, 100, 70, 30, BlockTypeIndestructible),
+		NewBlock(600, 100, 70, 30, BlockTypeNormal), // 範囲外
+	})
+
+	hitBlock(state, cfg, 0, NewRandomSource(nil))
+
+	want := []bool{false, false, false, true, true}
+	for i, alive := range want {
+		if state.Blocks[i].Alive != alive {
+			t.Fatalf("block %d alive=%v, want %v", i, state.Blocks[i].Alive, alive)
+		}
+	}
+	wantScore := 2*BlockSpec(BlockTypeExplosive).Points + BlockSpec(BlockTypeHard).Points
+	if state.Score != wantScore {
+		t.Fatalf("expected score %d, got %d", wantScore, state.Score)
+	}
+}
+
+func TestItemBlockAlwaysDropsItem(t *testing.T) {
+	cfg := baseLayout()
+	cfg.Drops.Chance = 0
+	state := NewGameState(cfg, []Block{NewBlock(100, 100, 70, 30, BlockTypeItem)})
+
+	hitBlock(state, cfg, 0, NewRandomSource(nil))
+
+	if len(state.Items) != 1 {
+		t.Fatalf("expected a guaranteed item, got %d", len(state.Items))
+	}
+}
+
+func TestPickBlockTypeByWeights(t *testing.T) {
+	cfg := baseLayout()
+	rnd := &mockRandom{floats: []float64{0.1, 0.6, 0.95}}
+
+	if got := pickBlockType(cfg, rnd); got != BlockTypeNormal || rnd.idx != 0 {
+		t.Fatalf("expected normal without drawing randomness, got %v (draws %d)", got, rnd.idx)
+	}
+
+	cfg.BlockTypeWeights = map[BlockType]float64{
+		BlockTypeNormal:    0.5,
+		BlockTypeHard:      0.4,
+		BlockTypeExplosive: 0.1,
+	}
+	want := []BlockType{BlockTypeNormal, BlockTypeHard, BlockTypeExplosive}
+	for i, w := range want {
+		if got := pickBlockType(cfg, rnd); got != w {
+			t.Fatalf("draw %d: expected %v, got %v", i, w, got)
+		}
+	}
+}
+
+func TestGenerateGridFallbackMixedTypes(t *testing.T) {
+	cfg := baseLayout()
+	cfg.BlockTypeWeights = map[BlockType]float64{BlockTypeHard: 1}
+
+	for i, b := range GenerateGridFallback(cfg, nil) {
+		if b.Type != BlockTypeHard || b.HP != BlockSpec(BlockTypeHard).HP {
+			t.Fatalf("block %d: expected full-HP hard block, got %+v", i, b)
+		}
+	}
+}
+
+func TestBlockBoundsIncludeRotation(t *testing.T) {
+	b := Block{X: 100, Y: 100, W: 40, H: 40, Shape: BlockShapeRotatedRect, Angle: math.Pi / 4}
+	x, y, w, h := b.Bounds()
+	want := 40 * math.Sqrt2
+	if math.Abs(w-want) > 1e-9 || math.Abs(h-want) > 1e-9 {
+		t.Fatalf("expected %fx%f bounds, got %fx%f", want, want, w, h)
+	}
+	if math.Abs(x+w/2-120) > 1e-9 || math.Abs(y+h/2-120) > 1e-9 {
+		t.Fatalf("expected bounds centred on the block, got (%f,%f)", x, y)
+	}
+}
+
+func TestScaleBlocksKeepsCentres(t *testing.T) {
+	blocks := []Block{NewBlock(100, 100, 70, 30, BlockTypeNormal)}
+	ScaleBlocks(blocks, 0.5)
+
+	b := blocks[0]
+	if b.W != 35 || b.H != 15 {
+		t.Fatalf("expected 35x15, got %fx%f", b.W, b.H)
+	}
+	if cx, cy := b.Center(); cx != 135 || cy != 115 {
+		t.Fatalf("expected centre to stay at (135,115), got (%f,%f)", cx, cy)
+	}
+}
+
+func TestSpawnItemUsesBlockCentre(t *testing.T) {
+	cfg := baseLayout()
+	state := NewGameState(cfg, []Block{})
+	block := Block{X: 200, Y: 50, W: 200, H: 10, Alive: true}
+
+	spawnItem(state, cfg, &block, ItemTypeMultiball)
+
+	item := state.Items[0]
+	if item.X+item.Width/2 != 300 || item.Y+item.Height/2 != 55 {
+		t.Fatalf("expected item centred on the wide block, got (%f,%f)", item.X, item.Y)
+	}
+}
@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	// defaultTickRate is used when LayoutConfig.TickRate is not set.
+	defaultTickRate = 60
+	// defaultMaxSteps bounds how many steps a single FixedStep.Add may release,
+	// so a long stall does not trigger an unbounded catch-up burst.
+	defaultMaxSteps = 8
+)
+
+// StepDuration returns the length of one fixed simulation step.
+func (c LayoutConfig) StepDuration() time.Duration {
+	return time.Second / time.Duration(c.tickRate())
+}
+
+// StepSeconds returns the length of one fixed simulation step in seconds.
+func (c LayoutConfig) StepSeconds() float64 {
+	return 1 / float64(c.tickRate())
+}
+
+// DurationTicks converts a duration in seconds into a whole number of fixed steps.
+func (c LayoutConfig) DurationTicks(seconds float64) int {
+	return int(math.Round(seconds * float64(c.tickRate())))
+}
+
+func (c LayoutConfig) tickRate() int {
+	if c.TickRate <= 0 {
+		return defaultTickRate
+	}
+	return c.TickRate
+}
+
+// FixedStep accumulates elapsed wall-clock time and releases it as whole fixed-size steps.
+// The leftover fraction is exposed through Alpha for render interpolation.
+type FixedStep struct {
+	Step     time.Duration
+	MaxSteps int
+	acc      time.Duration
+}
+
+// NewFixedStep creates an accumulator releasing steps of the given length.
+func NewFixedStep(step time.Duration) *FixedStep {
+	return &FixedStep{Step: step, MaxSteps: defaultMaxSteps}
+}
+
+// Add accumulates elapsed time and returns how many fixed steps should be simulated.
+// Time beyond MaxSteps is dropped so the simulation slows down instead of spiralling.
+func (f *FixedStep) Add(elapsed time.Duration) int {
+	if f.Step <= 0 || elapsed <= 0 {
+		return 0
+	}
+	f.acc += elapsed
+	steps := int(f.acc / f.Step)
+	if f.MaxSteps > 0 && steps > f.MaxSteps {
+		steps = f.MaxSteps
+		f.acc = f.acc % f.Step
+		return steps
+	}
+	f.acc -= time.Duration(steps) * f.Step
+	return steps
+}
+
+// Alpha returns the fraction of a step currently accumulated, in [0, 1).
+func (f *FixedStep) Alpha() float64 {
+	if f.Step <= 0 {
+		return 0
+	}
+	return float64(f.acc) / float64(f.Step)
+}
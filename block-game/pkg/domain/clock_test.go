@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFixedStepAccumulatesPartialSteps(t *testing.T) {
+	f := NewFixedStep(10 * time.Millisecond)
+
+	if got := f.Add(4 * time.Millisecond); got != 0 {
+		t.Fatalf("expected 0 steps, got %d", got)
+	}
+	if got := f.Alpha(); got != 0.4 {
+		t.Fatalf("expected alpha 0.4, got %f", got)
+	}
+	if got := f.Add(17 * time.Millisecond); got != 2 {
+		t.Fatalf("expected 2 steps, got %d", got)
+	}
+	if got := f.Alpha(); got < 0.099 || got > 0.101 {
+		t.Fatalf("expected alpha 0.1, got %f", got)
+	}
+}
+
+func TestFixedStepDropsExcessSteps(t *testing.T) {
+	f := NewFixedStep(10 * time.Millisecond)
+	f.MaxSteps = 3
+
+	if got := f.Add(105 * time.Millisecond); got != 3 {
+		t.Fatalf("expected steps capped at 3, got %d", got)
+	}
+	if got := f.Add(5 * time.Millisecond); got != 1 {
+		t.Fatalf("expected leftover to carry into next step, got %d", got)
+	}
+}
+
+func TestLayoutStepConversions(t *testing.T) {
+	cfg := LayoutConfig{}
+	if cfg.StepDuration() != time.Second/60 {
+		t.Fatalf("expected default 60Hz step, got %v", cfg.StepDuration())
+	}
+
+	cfg.TickRate = 120
+	if got := cfg.DurationTicks(5); got != 600 {
+		t.Fatalf("expected 600 ticks for 5s at 120Hz, got %d", got)
+	}
+	if got := cfg.StepSeconds(); got != 1.0/120 {
+		t.Fatalf("unexpected step seconds: %f", got)
+	}
+}
+
+// 描画側の TPS が違っても、同じ経過時間なら同じ状態になることを確認する
+func TestAdvanceIndependentOfFrameRate(t *testing.T) {
+	cfg := baseLayout()
+	run := func(tps int) *GameState {
+		state := NewGameState(cfg, []Block{})
+		clock := NewFixedStep(cfg.StepDuration())
+		frame := time.Second / time.Duration(tps)
+		for i := 0; i < tps; i++ {
+			for n := clock.Add(frame); n > 0; n-- {
+				Advance(state, InputState{MoveLeft: true, Launch: true}, cfg, NewRandomSource(nil))
+			}
+		}
+		return state
+	}
+
+	at30, at60 := run(30), run(60)
+	if at60.Tick != 60 {
+		t.Fatalf("expected 60 steps for one second, got %d", at60.Tick)
+	}
+	if at30.Tick != at60.Tick {
+		t.Fatalf("tick count differs: 30TPS=%d 60TPS=%d", at30.Tick, at60.Tick)
+	}
+	if at30.Paddle.X != at60.Paddle.X || at30.Balls[0].X != at60.Balls[0].X || at30.Balls[0].Y != at60.Balls[0].Y {
+		t.Fatalf("state differs between 30TPS and 60TPS")
+	}
+}
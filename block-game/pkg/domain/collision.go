@@ -0,0 +1,260 @@
+package domain
+
+import "math"
+
+// contactTimeEpsilon 以内の時刻差で起きた接触は同時とみなす
+const contactTimeEpsilon = 1e-9
+
+// surfaceKind はボールが接触した対象の種類
+type surfaceKind int
+
+const (
+	surfaceWall surfaceKind = iota
+	surfacePaddle
+	surfaceBlock
+)
+
+// Contact はステップ中に解決した接触の記録（デバッグ表示用）
+type Contact struct {
+	X, Y   float64 // 接触した瞬間のボール中心
+	NX, NY float64 // 接触面の法線（ボール側を向く単位ベクトル）
+	Block  int     // 当たったブロックの添字。壁・パドルなら -1
+}
+
+// contact は 1 回の掃引（sweep）で求めた接触
+type contact struct {
+	time    float64 // 残り移動量に対する割合 [0, 1]
+	nx, ny  float64 // 接触面の法線（ボール側を向く単位ベクトル）
+	surface surfaceKind
+	block   int // surfaceBlock のときのブロック添字
+}
+
+// record は接触を解決前のボール位置とともに Contact として記録する
+func (c contact) record(ball *Ball) Contact {
+	block := -1
+	if c.surface == surfaceBlock {
+		block = c.block
+	}
+	return Contact{X: ball.X, Y: ball.Y, NX: c.nx, NY: c.ny, Block: block}
+}
+
+// sweepCircleRect は (x, y) から (dx, dy) だけ移動する半径 r の円が
+// 矩形 (rx, ry, rw, rh) に最初に触れる時刻と法線を返す。
+// 矩形を r だけ膨らませた角丸矩形（ミンコフスキー和）と中心点のレイの交差として求め、
+// 辺に当たれば軸方向の法線、角に当たれば角から接触点へ向かう法線を返す。
+// 開始時点で既に重なっている場合は、離れる方向へ進んでいなければ time=0 の接触とする。
+func sweepCircleRect(x, y, dx, dy, r, rx, ry, rw, rh float64) (contact, bool) {
+	if c, overlapping := overlapCircleRect(x, y, r, rx, ry, rw, rh); overlapping {
+		if dx*c.nx+dy*c.ny >= 0 {
+			return contact{}, false
+		}
+		return c, true
+	}
+
+	minX, maxX := rx-r, rx+rw+r
+	minY, maxY := ry-r, ry+rh+r
+
+	// 膨張矩形への進入点を求める。内側にいるなら重なっていない以上、角の領域にいる。
+	px, py := x, y
+	var c contact
+	if !(x > minX && x < maxX && y > minY && y < maxY) {
+		enterX, exitX, okX := slab(x, dx, minX, maxX)
+		enterY, exitY, okY := slab(y, dy, minY, maxY)
+		if !okX || !okY {
+			return contact{}, false
+		}
+		enter := math.Max(enterX, enterY)
+		exit := math.Min(exitX, exitY)
+		if enter >= exit || enter < 0 || enter > 1 {
+			return contact{}, false
+		}
+		c.time = enter
+		if enterX > enterY {
+			c.nx = -math.Copysign(1, dx)
+		} else {
+			c.ny = -math.Copysign(1, dy)
+		}
+		px, py = x+dx*enter, y+dy*enter
+	}
+
+	outsideX := px < rx || px > rx+rw
+	outsideY := py < ry || py > ry+rh
+	if outsideX && outsideY {
+		// 角の領域では、角を中心とする半径 r の円との交差で判定し直す
+		cornerX, cornerY := rx, ry
+		if px > rx+rw {
+			cornerX = rx + rw
+		}
+		if py > ry+rh {
+			cornerY = ry + rh
+		}
+		t, ok := sweepCirclePoint(x, y, dx, dy, r, cornerX, cornerY)
+		if !ok {
+			return contact{}, false
+		}
+		c = contact{
+			time: t,
+			nx:   (x + dx*t - cornerX) / r,
+			ny:   (y + dy*t - cornerY) / r,
+		}
+	}
+
+	if dx*c.nx+dy*c.ny >= 0 {
+		return contact{}, false
+	}
+	return c, true
+}
+
+// sweepCircleBlock はブロックの形状に応じて円との接触を求める
+func sweepCircleBlock(x, y, dx, dy, r float64, b Block) (contact, bool) {
+	switch b.Shape {
+	case BlockShapeCircle:
+		cx, cy := b.Center()
+		return sweepCircleCircle(x, y, dx, dy, r+math.Min(b.W, b.H)/2, cx, cy)
+	case BlockShapeRotatedRect:
+		// ブロック中心を原点とする局所座標へ回転させて矩形として解き、法線だけ戻す
+		cx, cy := b.Center()
+		lx, ly := rotate(x-cx, y-cy, -b.Angle)
+		ldx, ldy := rotate(dx, dy, -b.Angle)
+		c, ok := sweepCircleRect(lx, ly, ldx, ldy, r, -b.W/2, -b.H/2, b.W, b.H)
+		if ok {
+			c.nx, c.ny = rotate(c.nx, c.ny, b.Angle)
+		}
+		return c, ok
+	default:
+		return sweepCircleRect(x, y, dx, dy, r, b.X, b.Y, b.W, b.H)
+	}
+}
+
+// sweepCircleCircle は中心 (cx, cy) までの距離が dist になる最初の時刻を返す（円同士の接触）
+func sweepCircleCircle(x, y, dx, dy, dist, cx, cy float64) (contact, bool) {
+	ox, oy := x-cx, y-cy
+	if d2 := ox*ox + oy*oy; d2 < dist*dist {
+		nx, ny := 0.0, -1.0
+		if d2 > 0 {
+			d := math.Sqrt(d2)
+			nx, ny = ox/d, oy/d
+		}
+		if dx*nx+dy*ny >= 0 {
+			return contact{}, false
+		}
+		return contact{nx: nx, ny: ny}, true
+	}
+	t, ok := sweepCirclePoint(x, y, dx, dy, dist, cx, cy)
+	if !ok {
+		return contact{}, false
+	}
+	return contact{
+		time: t,
+		nx:   (x + dx*t - cx) / dist,
+		ny:   (y + dy*t - cy) / dist,
+	}, true
+}
+
+// rotate はベクトルを angle ラジアン回転させる
+func rotate(x, y, angle float64) (float64, float64) {
+	sin, cos := math.Sincos(angle)
+	return x*cos - y*sin, x*sin + y*cos
+}
+
+// overlapCircleRect は円と矩形が重なっているとき、押し出し方向の法線を持つ time=0 の接触を返す
+func overlapCircleRect(x, y, r, rx, ry, rw, rh float64) (contact, bool) {
+	cx := math.Min(math.Max(x, rx), rx+rw)
+	cy := math.Min(math.Max(y, ry), ry+rh)
+	ox, oy := x-cx, y-cy
+	d2 := ox*ox + oy*oy
+	if d2 >= r*r {
+		return contact{}, false
+	}
+	if d2 > 0 {
+		d := math.Sqrt(d2)
+		return contact{nx: ox / d, ny: oy / d}, true
+	}
+	// 中心が矩形内部にある場合は最も浅い面から押し出す
+	nx, ny := penetrationNormal(x, y, rx, rx+rw, ry, ry+rh)
+	return contact{nx: nx, ny: ny}, true
+}
+
+// sweepCirclePoint は移動する半径 r の円が点 (px, py) に触れる時刻を返す
+func sweepCirclePoint(x, y, dx, dy, r, px, py float64) (float64, bool) {
+	mx, my := x-px, y-py
+	a := dx*dx + dy*dy
+	if a == 0 {
+		return 0, false
+	}
+	b := mx*dx + my*dy
+	c := mx*mx + my*my - r*r
+	disc := b*b - a*c
+	if disc < 0 {
+		return 0, false
+	}
+	t := (-b - math.Sqrt(disc)) / a
+	if t < 0 || t > 1 {
+		return 0, false
+	}
+	return t, true
+}
+
+// slab は 1 軸ぶんの進入・離脱時刻を返す。移動量 0 で区間外にいる場合は交差しない。
+func slab(p, d, lo, hi float64) (float64, float64, bool) {
+	if d == 0 {
+		if p <= lo || p >= hi {
+			return 0, 0, false
+		}
+		return math.Inf(-1), math.Inf(1), true
+	}
+	t1 := (lo - p) / d
+	t2 := (hi - p) / d
+	if t1 > t2 {
+		t1, t2 = t2, t1
+	}
+	return t1, t2, true
+}
+
+// penetrationNormal は矩形の内側にある点から最も近い面の法線を返す
+func penetrationNormal(x, y, minX, maxX, minY, maxY float64) (float64, float64) {
+	best := x - minX
+	nx, ny := -1.0, 0.0
+	if d := maxX - x; d < best {
+		best, nx, ny = d, 1, 0
+	}
+	if d := y - minY; d < best {
+		best, nx, ny = d, 0, -1
+	}
+	if d := maxY - y; d < best {
+		nx, ny = 0, 1
+	}
+	return nx, ny
+}
+
+// sweepWalls は左右・上の壁との最初の接触を返す。下端は落下判定のため壁として扱わない。
+func sweepWalls(x, y, dx, dy, r, screenW float64) (contact, bool) {
+	best := contact{time: math.Inf(1), surface: surfaceWall}
+	found := false
+
+	consider := func(t, nx, ny float64) {
+		if t < 0 {
+			t = 0
+		}
+		if t <= 1 && t < best.time {
+			best.time, best.nx, best.ny = t, nx, ny
+			found = true
+		}
+	}
+
+	if dx < 0 {
+		consider((r-x)/dx, 1, 0)
+	} else if dx > 0 {
+		consider((screenW-r-x)/dx, -1, 0)
+	}
+	if dy < 0 {
+		consider((r-y)/dy, 0, 1)
+	}
+	return best, found
+}
+
+// bounce は速度を法線に対して鏡映する
+func bounce(vx, vy, nx, ny float64) (float64, float64) {
+	dot := vx*nx + vy*ny
+	return vx - 2*dot*nx, vy - 2*dot*ny
+}
@@ -0,0 +1,187 @@
+package domain
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSweepCircleRect(t *testing.T) {
+	// 矩形 (100,100)-(170,130)、半径 10
+	const r = 10.0
+	inv := 1 / math.Sqrt2
+
+	tests := []struct {
+		name           string
+		x, y, dx, dy   float64
+		wantHit        bool
+		wantT          float64
+		wantNX, wantNY float64
+	}{
+		{name: "bottom face", x: 135, y: 150, dx: 0, dy: -20, wantHit: true, wantT: 0.5, wantNX: 0, wantNY: 1},
+		{name: "left face", x: 80, y: 115, dx: 20, dy: 0, wantHit: true, wantT: 0.5, wantNX: -1, wantNY: 0},
+		{name: "bottom-left corner head-on", x: 80, y: 150, dx: 20, dy: -20, wantHit: true, wantT: 1 - 10/(20*math.Sqrt2), wantNX: -inv, wantNY: inv},
+		{name: "corner region miss", x: 100, y: 130 + 12*math.Sqrt2, dx: -20, dy: -20, wantHit: false},
+		{name: "moving away", x: 135, y: 150, dx: 0, dy: 20, wantHit: false},
+		{name: "out of reach", x: 135, y: 200, dx: 0, dy: -20, wantHit: false},
+		{name: "overlapping and approaching", x: 135, y: 135, dx: 0, dy: -5, wantHit: true, wantT: 0, wantNX: 0, wantNY: 1},
+		{name: "overlapping and leaving", x: 135, y: 135, dx: 0, dy: 5, wantHit: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, ok := sweepCircleRect(tt.x, tt.y, tt.dx, tt.dy, r, 100, 100, 70, 30)
+			if ok != tt.wantHit {
+				t.Fatalf("hit=%v, want %v (contact %+v)", ok, tt.wantHit, c)
+			}
+			if !ok {
+				return
+			}
+			if math.Abs(c.time-tt.wantT) > 1e-9 {
+				t.Fatalf("time=%v, want %v", c.time, tt.wantT)
+			}
+			if math.Abs(c.nx-tt.wantNX) > 1e-9 || math.Abs(c.ny-tt.wantNY) > 1e-9 {
+				t.Fatalf("normal=(%v,%v), want (%v,%v)", c.nx, c.ny, tt.wantNX, tt.wantNY)
+			}
+		})
+	}
+}
+
+func TestBallServiceTrajectories(t *testing.T) {
+	blockA := Block{X: 100, Y: 100, W: 70, H: 30, Alive: true}
+	// blockA の右隣に接するブロック
+	blockRight := Block{X: 170, Y: 100, W: 70, H: 30, Alive: true}
+	// blockA の左下に置き、blockA の底面と右面で内角を作るブロック
+	blockLowerLeft := Block{X: 30, Y: 130, W: 70, H: 30, Alive: true}
+	d := 20 / math.Sqrt2
+
+	tests := []struct {
+		name           string
+		blocks         []Block
+		x, y, vx, vy   float64
+		wantX, wantY   float64
+		wantVX, wantVY float64
+		wantAlive      []bool
+	}{
+		{
+			name:   "face hit reflects one axis",
+			blocks: []Block{blockA},
+			x:      135, y: 150, vx: 0, vy: -20,
+			wantX: 135, wantY: 150, wantVX: 0, wantVY: 20,
+			wantAlive: []bool{false},
+		},
+		{
+			name:   "corner hit reflects about the corner normal",
+			blocks: []Block{blockA},
+			x:      80, y: 150, vx: 20, vy: -20,
+			wantX: 100 - d, wantY: 130 + d, wantVX: -20, wantVY: 20,
+			wantAlive: []bool{false},
+		},
+		{
+			name:   "glancing pass by a corner does not collide",
+			blocks: []Block{blockA},
+			x:      100, y: 130 + 12*math.Sqrt2, vx: -20, vy: -20,
+			wantX: 80, wantY: 110 + 12*math.Sqrt2, wantVX: -20, wantVY: -20,
+			wantAlive: []bool{true},
+		},
+		{
+			name:   "seam between adjacent blocks reflects once and hits both",
+			blocks: []Block{blockA, blockRight},
+			x:      170, y: 150, vx: 0, vy: -20,
+			wantX: 170, wantY: 150, wantVX: 0, wantVY: 20,
+			wantAlive: []bool{false, false},
+		},
+		{
+			name:   "inner corner reflects both axes once",
+			blocks: []Block{blockA, blockLowerLeft},
+			x:      120, y: 150, vx: -20, vy: -20,
+			wantX: 120, wantY: 150, wantVX: 20, wantVY: 20,
+			wantAlive: []bool{false, false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := baseLayout()
+			cfg.TickRate = 1 // 1 ステップ = 1 秒として速度をそのまま移動量で表す
+			cfg.Drops.Chance = 0
+			blocks := append([]Block(nil), tt.blocks...)
+			state := newLaunchedState(cfg, blocks)
+			state.Balls[0] = Ball{X: tt.x, Y: tt.y, VX: tt.vx, VY: tt.vy, Radius: 10}
+
+			NewBallService().Advance(state, cfg, NewRandomSource(nil))
+
+			b := state.Balls[0]
+			if math.Abs(b.X-tt.wantX) > 1e-9 || math.Abs(b.Y-tt.wantY) > 1e-9 {
+				t.Fatalf("position=(%v,%v), want (%v,%v)", b.X, b.Y, tt.wantX, tt.wantY)
+			}
+			if math.Abs(b.VX-tt.wantVX) > 1e-9 || math.Abs(b.VY-tt.wantVY) > 1e-9 {
+				t.Fatalf("velocity=(%v,%v), want (%v,%v)", b.VX, b.VY, tt.wantVX, tt.wantVY)
+			}
+			for i, want := range tt.wantAlive {
+				if state.Blocks[i].Alive != want {
+					t.Fatalf("block %d alive=%v, want %v", i, state.Blocks[i].Alive, want)
+				}
+			}
+		})
+	}
+}
+
+func TestBallServiceShapedBlockTrajectories(t *testing.T) {
+	// 中心 (120,120)、40x40 の円形バンパーと 45 度回転した正方形
+	bumper := Block{X: 100, Y: 100, W: 40, H: 40, Shape: BlockShapeCircle, Alive: true, Type: BlockTypeIndestructible}
+	diamond := Block{X: 100, Y: 100, W: 40, H: 40, Shape: BlockShapeRotatedRect, Angle: math.Pi / 4, Alive: true, Type: BlockTypeIndestructible}
+	// ひし形の右下の面 (法線 (1,1)/√2) に x=130 で接する中心の y
+	faceY := 120 + 30*math.Sqrt2 - 10
+
+	tests := []struct {
+		name           string
+		block          Block
+		x, y, vx, vy   float64
+		wantX, wantY   float64
+		wantVX, wantVY float64
+	}{
+		{
+			name:  "circle head-on",
+			block: bumper,
+			x:     120, y: 160, vx: 0, vy: -20,
+			wantX: 120, wantY: 160, wantVX: 0, wantVY: 20,
+		},
+		{
+			name:  "circle off-centre deflects by the contact normal",
+			block: bumper,
+			x:     135, y: 120 + math.Sqrt(900-225) + 10, vx: 0, vy: -20,
+			wantX: 135 + 10*math.Sqrt(3)/2, wantY: 120 + math.Sqrt(900-225) + 5, wantVX: 20 * math.Sqrt(3) / 2, wantVY: 10,
+		},
+		{
+			name:  "rotated rect face deflects sideways",
+			block: diamond,
+			x:     130, y: faceY + 20, vx: 0, vy: -40,
+			wantX: 150, wantY: faceY, wantVX: 40, wantVY: 0,
+		},
+		{
+			name:  "rotated rect vertex reflects straight back",
+			block: diamond,
+			x:     120, y: 120 + 20*math.Sqrt2 + 30, vx: 0, vy: -40,
+			wantX: 120, wantY: 120 + 20*math.Sqrt2 + 30, wantVX: 0, wantVY: 40,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := baseLayout()
+			cfg.TickRate = 1
+			state := newLaunchedState(cfg, []Block{tt.block})
+			state.Balls[0] = Ball{X: tt.x, Y: tt.y, VX: tt.vx, VY: tt.vy, Radius: 10}
+
+			NewBallService().Advance(state, cfg, NewRandomSource(nil))
+
+			b := state.Balls[0]
+			if math.Abs(b.X-tt.wantX) > 1e-9 || math.Abs(b.Y-tt.wantY) > 1e-9 {
+				t.Fatalf("position=(%v,%v), want (%v,%v)", b.X, b.Y, tt.wantX, tt.wantY)
+			}
+			if math.Abs(b.VX-tt.wantVX) > 1e-9 || math.Abs(b.VY-tt.wantVY) > 1e-9 {
+				t.Fatalf("velocity=(%v,%v), want (%v,%v)", b.VX, b.VY, tt.wantVX, tt.wantVY)
+			}
+		})
+	}
+}
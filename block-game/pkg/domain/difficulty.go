@@ -27,6 +27,7 @@ type DifficultySetting struct {
 	PaddleSpeedScale float64
 	BlockSizeScale   float64
 	BlockCountScale  float64
+	HazardWeights    map[ItemType]float64 // drop weights of harmful items; those left out never drop
 }
 
 // DifficultyProfile stores the available settings and default selection.
@@ -55,6 +56,10 @@ func DefaultDifficultyProfile() DifficultyProfile {
 			PaddleSpeedScale: 1.0,
 			BlockSizeScale:   1.0,
 			BlockCountScale:  1.0,
+			HazardWeights: map[ItemType]float64{
+				ItemTypePaddleShrink: 0.5,
+				ItemTypeBallSpeedUp:  0.5,
+			},
 		},
 		DifficultyHard: {
 			Name:             DifficultyHard,
@@ -64,6 +69,11 @@ func DefaultDifficultyProfile() DifficultyProfile {
 			PaddleSpeedScale: 0.9,
 			BlockSizeScale:   0.9,
 			BlockCountScale:  1.3,
+			HazardWeights: map[ItemType]float64{
+				ItemTypePaddleShrink:    1,
+				ItemTypeBallSpeedUp:     1,
+				ItemTypeReverseControls: 1,
+			},
 		},
 	}
 	return DifficultyProfile{
@@ -144,6 +154,11 @@ func clampSetting(setting DifficultySetting) (DifficultySetting, error) {
 	if err != nil {
 		return DifficultySetting{}, fmt.Errorf("block count scale: %w", err)
 	}
+	for t, w := range setting.HazardWeights {
+		if w < 0 {
+			return DifficultySetting{}, fmt.Errorf("hazard weight of %s: must not be negative", itemTypeSpecs[t].Name)
+		}
+	}
 	return setting, nil
 }
 
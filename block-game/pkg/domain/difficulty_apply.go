@@ -24,6 +24,16 @@ func ApplyDifficulty(base LayoutConfig, setting DifficultySetting) (LayoutConfig
 	derived.PaddleSpeed = base.PaddleSpeed * setting.PaddleSpeedScale
 	derived.BlockW = base.BlockW * setting.BlockSizeScale
 	derived.BlockH = base.BlockH * setting.BlockSizeScale
+	derived.BlockSizeScale = setting.BlockSizeScale
+
+	// Copy the hazard weights so the derived layout never shares the profile's map.
+	derived.HazardWeights = nil
+	if setting.HazardWeights != nil {
+		derived.HazardWeights = make(map[ItemType]float64, len(setting.HazardWeights))
+		for t, w := range setting.HazardWeights {
+			derived.HazardWeights[t] = w
+		}
+	}
 
 	// Scale block count with rounding and enforce minimum of 1.
 	scaledCount := int(math.Round(float64(base.BlockCount) * setting.BlockCountScale))
@@ -56,6 +56,7 @@ func TestApplyDifficultyScalesValues(t *testing.T) {
 	assertFloatClose(t, derived.PaddleSpeed, base.PaddleSpeed*setting.PaddleSpeedScale, "paddle speed not scaled")
 	assertFloatClose(t, derived.BlockW, base.BlockW*setting.BlockSizeScale, "block width not scaled")
 	assertFloatClose(t, derived.BlockH, base.BlockH*setting.BlockSizeScale, "block height not scaled")
+	assertFloatClose(t, derived.BlockSizeScale, setting.BlockSizeScale, "block size scale not recorded")
 
 	expectedCount := int(math.Round(float64(base.BlockCount) * setting.BlockCountScale))
 	if derived.BlockCount != expectedCount {
@@ -89,6 +90,22 @@ func TestApplyDifficultyRejectsNonPositiveScale(t *testing.T) {
 	}
 }
 
+func TestApplyDifficultyCopiesHazardWeights(t *testing.T) {
+	setting := DefaultDifficultyProfile().Settings[DifficultyHard]
+	derived, err := ApplyDifficulty(baseLayoutForDifficulty(), setting)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if derived.HazardWeights[ItemTypeBallSpeedUp] != setting.HazardWeights[ItemTypeBallSpeedUp] {
+		t.Fatalf("expected the hazard weights applied, got %v", derived.HazardWeights)
+	}
+
+	derived.HazardWeights[ItemTypeBallSpeedUp] = 99
+	if setting.HazardWeights[ItemTypeBallSpeedUp] == 99 {
+		t.Fatal("editing the layout's hazard weights changed the difficulty setting")
+	}
+}
+
 func assertFloatClose(t *testing.T, got, want float64, msg string) {
 	t.Helper()
 	const eps = 1e-9
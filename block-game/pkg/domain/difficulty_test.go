@@ -80,3 +80,27 @@ func TestClampAboveMaxScale(t *testing.T) {
 		t.Fatalf("expected clamped scale %f, got %f", maxScale, setting.BallSpeedScale)
 	}
 }
+
+func TestHazardWeightsRiseWithDifficulty(t *testing.T) {
+	profile := DefaultDifficultyProfile()
+	total := func(d Difficulty) float64 {
+		sum := 0.0
+		for _, w := range profile.Settings[d].HazardWeights {
+			sum += w
+		}
+		return sum
+	}
+	if total(DifficultyEasy) != 0 {
+		t.Fatalf("expected no hazards on EASY, got %v", profile.Settings[DifficultyEasy].HazardWeights)
+	}
+	if !(total(DifficultyNormal) < total(DifficultyHard)) {
+		t.Fatalf("expected HARD to drop more hazards than NORMAL")
+	}
+
+	invalid := profile.Settings[DifficultyHard]
+	invalid.HazardWeights = map[ItemType]float64{ItemTypeBallSpeedUp: -1}
+	profile.Settings[DifficultyHard] = invalid
+	if _, applied, err := NewDifficultyValidator(DifficultyNormal).Validate(profile, DifficultyHard); err == nil || applied != DifficultyNormal {
+		t.Fatalf("expected a negative hazard weight to fall back, got %s (%v)", applied, err)
+	}
+}
@@ -0,0 +1,253 @@
+package domain
+
+// EffectKind identifies a timed effect.
+type EffectKind int
+
+const (
+	EffectPaddleEnlarge EffectKind = iota
+	EffectLaser                    // the paddle fires projectiles on InputState.Fire
+	EffectCatch                    // balls stick to the paddle until launched
+	EffectFireball                 // balls pierce blocks (see Ball.Piercing)
+	EffectPaddleShrink
+	EffectBallSpeedUp
+	EffectReverseControls // swaps InputState.MoveLeft and MoveRight
+	EffectBallSlow
+)
+
+// StackRule decides what starting an effect does while it is already active.
+type StackRule int
+
+const (
+	StackRefresh   StackRule = iota // restart the timer at the full duration
+	StackExtend                     // add the full duration to the time left
+	StackStack                      // apply once more on top (up to MaxStacks) and restart the timer
+	StackExclusive                  // end the other effects of the same Group, then refresh
+)
+
+// Modifier is a quantity that effects scale multiplicatively. Scaled values are always
+// recomputed from their base, so effects can end in any order and still revert exactly.
+type Modifier int
+
+const (
+	ModNone        Modifier = iota // the effect scales nothing (flag-like effects)
+	ModPaddleWidth                 // Paddle.Width relative to Paddle.BaseWidth
+	ModBallSpeed                   // ball velocity relative to Ball.Speed
+)
+
+// EffectKindSpec describes how an effect kind behaves.
+type EffectKindSpec struct {
+	Name      string // label shown in the HUD
+	Stack     StackRule
+	MaxStacks int                            // StackStack: most applications in force at once (at least 1)
+	Group     string                         // StackExclusive: effects sharing a group are never active together
+	Modifies  Modifier                       // quantity scaled by Magnitude per stack
+	Duration  func(cfg LayoutConfig) float64 // seconds
+	Magnitude func(cfg LayoutConfig) float64 // strength of one application; nil means 1
+
+	// Apply runs after the effect started or gained a stack, Revert after it ended.
+	// Modifiers are already up to date when they run. Either may be nil.
+	Apply  func(state *GameState)
+	Revert func(state *GameState)
+}
+
+var effectKindSpecs = map[EffectKind]EffectKindSpec{
+	EffectPaddleEnlarge: {
+		Name:      "PADDLE",
+		Stack:     StackRefresh,
+		Modifies:  ModPaddleWidth,
+		Duration:  func(cfg LayoutConfig) float64 { return cfg.PaddleEnlargeDuration },
+		Magnitude: func(cfg LayoutConfig) float64 { return cfg.PaddleEnlargeMultiplier },
+	},
+	EffectLaser: {
+		Name:     "LASER",
+		Stack:    StackRefresh,
+		Duration: func(cfg LayoutConfig) float64 { return cfg.LaserDuration },
+	},
+	EffectCatch: {
+		Name:     "CATCH",
+		Stack:    StackRefresh,
+		Duration: func(cfg LayoutConfig) float64 { return cfg.CatchDuration },
+	},
+	EffectFireball: {
+		Name:     "FIREBALL",
+		Stack:    StackRefresh,
+		Duration: func(cfg LayoutConfig) float64 { return cfg.FireballDuration },
+		Apply:    func(state *GameState) { setPiercing(state, true) },
+		Revert:   func(state *GameState) { setPiercing(state, false) },
+	},
+	EffectPaddleShrink: {
+		Name:      "SHRINK",
+		Stack:     StackRefresh,
+		Modifies:  ModPaddleWidth,
+		Duration:  func(cfg LayoutConfig) float64 { return cfg.PaddleShrinkDuration },
+		Magnitude: func(cfg LayoutConfig) float64 { return cfg.PaddleShrinkMultiplier },
+	},
+	EffectBallSpeedUp: {
+		Name:      "SPEED",
+		Stack:     StackRefresh,
+		Modifies:  ModBallSpeed,
+		Duration:  func(cfg LayoutConfig) float64 { return cfg.BallSpeedUpDuration },
+		Magnitude: func(cfg LayoutConfig) float64 { return cfg.BallSpeedUpMultiplier },
+	},
+	EffectReverseControls: {
+		Name:     "REVERSE",
+		Stack:    StackRefresh,
+		Duration: func(cfg LayoutConfig) float64 { return cfg.ReverseControlsDuration },
+	},
+	EffectBallSlow: {
+		Name:      "SLOW",
+		Stack:     StackRefresh,
+		Modifies:  ModBallSpeed,
+		Duration:  func(cfg LayoutConfig) float64 { return cfg.BallSlowDuration },
+		Magnitude: func(cfg LayoutConfig) float64 { return cfg.BallSlowMultiplier },
+	},
+}
+
+// EffectSpec returns the spec of the given effect kind and whether it is registered.
+func EffectSpec(kind EffectKind) (EffectKindSpec, bool) {
+	spec, ok := effectKindSpecs[kind]
+	return spec, ok
+}
+
+// ActiveEffect is an effect in force.
+type ActiveEffect struct {
+	Kind           EffectKind
+	RemainingTicks int     // fixed steps left (see LayoutConfig.DurationTicks)
+	Stacks         int     // applications in force; 1 unless the kind stacks
+	Magnitude      float64 // strength of one application, fixed when the effect started
+}
+
+// Scale returns the factor the effect applies to its modifier.
+func (e ActiveEffect) Scale() float64 {
+	scale := 1.0
+	for i := 0; i < e.Stacks; i++ {
+		scale *= e.Magnitude
+	}
+	return scale
+}
+
+// Effect returns the active effect of the given kind.
+func (s *GameState) Effect(kind EffectKind) (ActiveEffect, bool) {
+	for _, e := range s.Effects {
+		if e.Kind == kind {
+			return e, true
+		}
+	}
+	return ActiveEffect{}, false
+}
+
+// Modifier returns the product of the scales of every active effect on m.
+func (s *GameState) Modifier(m Modifier) float64 {
+	scale := 1.0
+	for _, e := range s.Effects {
+		if spec, _ := EffectSpec(e.Kind); spec.Modifies == m && m != ModNone {
+			scale *= e.Scale()
+		}
+	}
+	return scale
+}
+
+// StartEffect starts an effect of the given kind, following its stack rule if it is
+// already active. Unregistered kinds are ignored.
+func StartEffect(state *GameState, cfg LayoutConfig, kind EffectKind) {
+	spec, ok := EffectSpec(kind)
+	if !ok {
+		return
+	}
+	ticks := cfg.DurationTicks(spec.Duration(cfg))
+	magnitude := 1.0
+	if spec.Magnitude != nil {
+		magnitude = spec.Magnitude(cfg)
+	}
+
+	if spec.Stack == StackExclusive && spec.Group != "" {
+		for i := len(state.Effects) - 1; i >= 0; i-- {
+			other, _ := EffectSpec(state.Effects[i].Kind)
+			if state.Effects[i].Kind != kind && other.Group == spec.Group {
+				endEffect(state, i)
+			}
+		}
+	}
+
+	i := state.effectIndex(kind)
+	refreshed := i >= 0
+	if !refreshed {
+		state.Effects = append(state.Effects, ActiveEffect{Kind: kind, RemainingTicks: ticks, Stacks: 1, Magnitude: magnitude})
+		i = len(state.Effects) - 1
+	} else {
+		e := &state.Effects[i]
+		switch spec.Stack {
+		case StackExtend:
+			e.RemainingTicks += ticks
+		case StackStack:
+			if e.Stacks < max(spec.MaxStacks, 1) {
+				e.Stacks++
+			}
+			e.RemainingTicks = ticks
+		default:
+			e.RemainingTicks = ticks
+		}
+	}
+
+	applyModifiers(state)
+	if spec.Apply != nil {
+		spec.Apply(state)
+	}
+	state.emit(EffectStarted{Effect: kind, Refreshed: refreshed, Ticks: state.Effects[i].RemainingTicks})
+}
+
+// updateEffects counts every active effect down by one step and ends those that ran out.
+func updateEffects(state *GameState) {
+	for i := 0; i < len(state.Effects); {
+		state.Effects[i].RemainingTicks--
+		if state.Effects[i].RemainingTicks <= 0 {
+			endEffect(state, i)
+			continue
+		}
+		i++
+	}
+}
+
+// clearEffects ends every active effect, e.g. when a life is lost.
+func clearEffects(state *GameState) {
+	for len(state.Effects) > 0 {
+		endEffect(state, 0)
+	}
+}
+
+// endEffect removes effect i, keeping the order of the others, and reverts it.
+func endEffect(state *GameState, i int) {
+	kind := state.Effects[i].Kind
+	state.Effects = append(state.Effects[:i], state.Effects[i+1:]...)
+	applyModifiers(state)
+	if spec, _ := EffectSpec(kind); spec.Revert != nil {
+		spec.Revert(state)
+	}
+	state.emit(EffectExpired{Effect: kind})
+}
+
+func (s *GameState) effectIndex(kind EffectKind) int {
+	for i, e := range s.Effects {
+		if e.Kind == kind {
+			return i
+		}
+	}
+	return -1
+}
+
+// setPiercing sets the fireball flag of every ball. Balls split off later copy it.
+func setPiercing(state *GameState, on bool) {
+	for i := range state.Balls {
+		state.Balls[i].Piercing = on
+	}
+}
+
+// applyModifiers recomputes every scaled quantity from its base.
+func applyModifiers(state *GameState) {
+	state.Paddle.Width = state.Paddle.BaseWidth * state.Modifier(ModPaddleWidth)
+
+	scale := state.Modifier(ModBallSpeed)
+	for i := range state.Balls {
+		state.Balls[i].setSpeed(scale)
+	}
+}
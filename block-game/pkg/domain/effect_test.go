@@ -0,0 +1,347 @@
+package domain
+
+import (
+	"math"
+	"testing"
+)
+
+const (
+	testEffectGrow EffectKind = 100 + iota
+	testEffectShrink
+	testEffectFlagA
+	testEffectFlagB
+)
+
+// withEffect registers spec for the duration of the test.
+func withEffect(t *testing.T, kind EffectKind, spec EffectKindSpec) {
+	t.Helper()
+	if spec.Duration == nil {
+		spec.Duration = func(LayoutConfig) float64 { return 10.0 / 60 }
+	}
+	effectKindSpecs[kind] = spec
+	t.Cleanup(func() { delete(effectKindSpecs, kind) })
+}
+
+func magnitude(m float64) func(LayoutConfig) float64 {
+	return func(LayoutConfig) float64 { return m }
+}
+
+func TestStartEffectStackRules(t *testing.T) {
+	tests := []struct {
+		name       string
+		spec       EffectKindSpec
+		starts     int
+		wantTicks  int
+		wantStacks int
+	}{
+		{"refresh", EffectKindSpec{Stack: StackRefresh}, 3, 10, 1},
+		{"extend", EffectKindSpec{Stack: StackExtend}, 3, 30, 1},
+		{"stack up to the limit", EffectKindSpec{Stack: StackStack, MaxStacks: 2}, 3, 10, 2},
+		{"exclusive refreshes itself", EffectKindSpec{Stack: StackExclusive, Group: "g"}, 2, 10, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withEffect(t, testEffectFlagA, tt.spec)
+			cfg := baseLayout()
+			state := NewGameState(cfg, nil)
+			for i := 0; i < tt.starts; i++ {
+				StartEffect(state, cfg, testEffectFlagA)
+			}
+			e, ok := state.Effect(testEffectFlagA)
+			if !ok || len(state.Effects) != 1 {
+				t.Fatalf("expected one active effect, got %+v", state.Effects)
+			}
+			if e.RemainingTicks != tt.wantTicks || e.Stacks != tt.wantStacks {
+				t.Fatalf("got %d ticks and %d stacks, want %d and %d", e.RemainingTicks, e.Stacks, tt.wantTicks, tt.wantStacks)
+			}
+		})
+	}
+}
+
+func TestExclusiveEffectEndsOthersInGroup(t *testing.T) {
+	withEffect(t, testEffectFlagA, EffectKindSpec{Stack: StackExclusive, Group: "control"})
+	withEffect(t, testEffectFlagB, EffectKindSpec{Stack: StackExclusive, Group: "control"})
+	withEffect(t, testEffectGrow, EffectKindSpec{Modifies: ModPaddleWidth, Magnitude: magnitude(2)})
+	cfg := baseLayout()
+	state := NewGameState(cfg, nil)
+
+	StartEffect(state, cfg, testEffectFlagA)
+	StartEffect(state, cfg, testEffectGrow)
+	StartEffect(state, cfg, testEffectFlagB)
+
+	if _, ok := state.Effect(testEffectFlagA); ok {
+		t.Fatalf("starting B should have ended A")
+	}
+	if _, ok := state.Effect(testEffectGrow); !ok {
+		t.Fatalf("effects outside the group should stay")
+	}
+	assertEvents(t, state.Events, []Event{
+		EffectStarted{Effect: testEffectFlagA, Ticks: 10},
+		EffectStarted{Effect: testEffectGrow, Ticks: 10},
+		EffectExpired{Effect: testEffectFlagA},
+		EffectStarted{Effect: testEffectFlagB, Ticks: 10},
+	})
+}
+
+func TestConcurrentEffectsRevertInAnyOrder(t *testing.T) {
+	withEffect(t, testEffectGrow, EffectKindSpec{Modifies: ModPaddleWidth, Magnitude: magnitude(3)})
+	withEffect(t, testEffectShrink, EffectKindSpec{
+		Modifies:  ModPaddleWidth,
+		Magnitude: magnitude(0.5),
+		Duration:  func(LayoutConfig) float64 { return 5.0 / 60 },
+	})
+	cfg := baseLayout()
+	base := cfg.PaddleWidth
+
+	for _, order := range [][]EffectKind{{testEffectGrow, testEffectShrink}, {testEffectShrink, testEffectGrow}} {
+		state := NewGameState(cfg, nil)
+		StartEffect(state, cfg, order[0])
+		StartEffect(state, cfg, order[1])
+		if state.Paddle.Width != base*1.5 {
+			t.Fatalf("both effects: expected width %v, got %v", base*1.5, state.Paddle.Width)
+		}
+		// The shrink effect is shorter, so it ends first whichever started first.
+		for i := 0; i < 5; i++ {
+			updateEffects(state)
+		}
+		if state.Paddle.Width != base*3 {
+			t.Fatalf("after shrink ended: expected width %v, got %v", base*3, state.Paddle.Width)
+		}
+		for i := 0; i < 5; i++ {
+			updateEffects(state)
+		}
+		if state.Paddle.Width != base || len(state.Effects) != 0 {
+			t.Fatalf("after both ended: expected width %v, got %v with %+v", base, state.Paddle.Width, state.Effects)
+		}
+	}
+}
+
+func TestEffectHooksAndStackedScale(t *testing.T) {
+	var applied, reverted int
+	withEffect(t, testEffectGrow, EffectKindSpec{
+		Stack:     StackStack,
+		MaxStacks: 3,
+		Modifies:  ModPaddleWidth,
+		Magnitude: magnitude(2),
+		Apply:     func(*GameState) { applied++ },
+		Revert:    func(*GameState) { reverted++ },
+	})
+	cfg := baseLayout()
+	state := NewGameState(cfg, nil)
+
+	StartEffect(state, cfg, testEffectGrow)
+	StartEffect(state, cfg, testEffectGrow)
+	if state.Paddle.Width != cfg.PaddleWidth*4 || state.Modifier(ModPaddleWidth) != 4 {
+		t.Fatalf("two stacks of x2 should give x4, got width %v", state.Paddle.Width)
+	}
+	clearEffects(state)
+	if applied != 2 || reverted != 1 {
+		t.Fatalf("expected 2 applies and 1 revert, got %d and %d", applied, reverted)
+	}
+	if state.Paddle.Width != cfg.PaddleWidth {
+		t.Fatalf("expected the base width back, got %v", state.Paddle.Width)
+	}
+}
+
+func TestStartEffectIgnoresUnknownKinds(t *testing.T) {
+	cfg := baseLayout()
+	state := NewGameState(cfg, nil)
+	StartEffect(state, cfg, EffectKind(-1))
+	if len(state.Effects) != 0 || len(state.Events) != 0 {
+		t.Fatalf("unknown effect kinds should be ignored")
+	}
+}
+
+func TestFireballFlagsEveryBallWhileActive(t *testing.T) {
+	cfg := baseLayout()
+	cfg.MaxBalls = 4
+	cfg.FireballDuration = 2.0 / 60
+	state := newLaunchedState(cfg, []Block{})
+
+	StartEffect(state, cfg, EffectFireball)
+	applyMultiball(state, cfg)
+	for i, b := range state.Balls {
+		if !b.Piercing {
+			t.Fatalf("expected ball %d to pierce while the fireball is active", i)
+		}
+	}
+
+	updateEffects(state)
+	updateEffects(state)
+	for i, b := range state.Balls {
+		if b.Piercing {
+			t.Fatalf("expected ball %d to stop piercing after the fireball expired", i)
+		}
+	}
+}
+
+func TestPaddleShrinkComposesWithEnlarge(t *testing.T) {
+	cfg := baseLayout()
+	cfg.PaddleEnlargeDuration = 2.0 / 60
+	cfg.PaddleEnlargeMultiplier = 2
+	cfg.PaddleShrinkDuration = 4.0 / 60
+	cfg.PaddleShrinkMultiplier = 0.5
+	state := NewGameState(cfg, nil)
+	base := state.Paddle.BaseWidth
+
+	StartEffect(state, cfg, EffectPaddleShrink)
+	StartEffect(state, cfg, EffectPaddleEnlarge)
+	if state.Paddle.Width != base {
+		t.Fatalf("expected shrink and enlarge to cancel out, got width %v", state.Paddle.Width)
+	}
+
+	updateEffects(state)
+	updateEffects(state)
+	if state.Paddle.Width != base*0.5 {
+		t.Fatalf("expected only the shrink left, got width %v", state.Paddle.Width)
+	}
+
+	updateEffects(state)
+	updateEffects(state)
+	if len(state.Effects) != 0 || state.Paddle.Width != base {
+		t.Fatalf("expected the base width back, got %v with effects %+v", state.Paddle.Width, state.Effects)
+	}
+}
+
+func TestBallSpeedUpScalesEveryBall(t *testing.T) {
+	cfg := baseLayout()
+	cfg.MaxBalls = 4
+	cfg.BallSpeedUpDuration = 2.0 / 60
+	cfg.BallSpeedUpMultiplier = 1.5
+	state := newLaunchedState(cfg, []Block{})
+	state.Balls = append(state.Balls, Ball{Radius: cfg.BallRadius, VX: 30, VY: -40, Speed: 50})
+	before := append([]Ball(nil), state.Balls...)
+
+	StartEffect(state, cfg, EffectBallSpeedUp)
+	for i, b := range state.Balls {
+		if b.VX != before[i].VX*1.5 || b.VY != before[i].VY*1.5 {
+			t.Fatalf("expected ball %d sped up, got %+v from %+v", i, b, before[i])
+		}
+	}
+
+	StartEffect(state, cfg, EffectBallSpeedUp)
+	if state.Balls[1].VX != 45 {
+		t.Fatalf("expected a refresh not to speed up again, got %+v", state.Balls[1])
+	}
+
+	updateEffects(state)
+	updateEffects(state)
+	for i, b := range state.Balls {
+		if b.VX != before[i].VX || b.VY != before[i].VY {
+			t.Fatalf("expected ball %d back at its speed, got %+v from %+v", i, b, before[i])
+		}
+	}
+}
+
+func TestSpedUpBallsLaunchFaster(t *testing.T) {
+	cfg := baseLayout()
+	cfg.BallSpeedUpDuration = 1
+	cfg.BallSpeedUpMultiplier = 2
+	state := NewGameState(cfg, nil)
+
+	StartEffect(state, cfg, EffectBallSpeedUp)
+	updateAttachedBalls(state, InputState{Launch: true}, cfg)
+
+	b := state.Balls[0]
+	if got := math.Hypot(b.VX, b.VY); math.Abs(got-2*cfg.BallSpeed) > 1e-9 {
+		t.Fatalf("expected launch speed %v, got %v", 2*cfg.BallSpeed, got)
+	}
+}
+
+func TestReverseControlsSwapMoves(t *testing.T) {
+	cfg := baseLayout()
+	cfg.ReverseControlsDuration = 1
+	state := NewGameState(cfg, nil)
+	x := state.Paddle.X
+
+	StartEffect(state, cfg, EffectReverseControls)
+	Advance(state, InputState{MoveLeft: true}, cfg, NewRandomSource(nil))
+
+	if want := x + cfg.PaddleSpeed*cfg.StepSeconds(); state.Paddle.X != want {
+		t.Fatalf("expected left to move the paddle right to %v, got %v", want, state.Paddle.X)
+	}
+}
+
+func TestBallSlowRestoresSpeedsAfterSplitAndPaddle(t *testing.T) {
+	cfg := baseLayout()
+	cfg.MaxBalls = 4
+	cfg.BallSlowDuration = 2.0 / 60
+	cfg.BallSlowMultiplier = 0.5
+	state := newLaunchedState(cfg, []Block{})
+	state.Balls[0].Speed = 300
+	state.Balls[0].setSpeed(1)
+
+	StartEffect(state, cfg, EffectBallSlow)
+	applyMultiball(state, cfg)
+	// send the split ball into the paddle while slowed
+	b := &state.Balls[1]
+	b.X, b.Y = state.Paddle.X+state.Paddle.Width/4, state.Paddle.Y-b.Radius-1
+	b.VX, b.VY = 0, 150
+	NewBallService().Advance(state, cfg, NewRandomSource(nil))
+	for i, b := range state.Balls {
+		if got := math.Hypot(b.VX, b.VY); math.Abs(got-150) > 1e-9 {
+			t.Fatalf("expected ball %d slowed to 150, got %v", i, got)
+		}
+	}
+	if state.Balls[1].VY >= 0 {
+		t.Fatalf("expected the split ball to bounce off the paddle, got %+v", state.Balls[1])
+	}
+
+	updateEffects(state)
+	updateEffects(state)
+	for i, b := range state.Balls {
+		if got := math.Hypot(b.VX, b.VY); math.Abs(got-300) > 1e-9 {
+			t.Fatalf("expected ball %d back at 300, got %v", i, got)
+		}
+	}
+}
+
+func TestSlowAndSpeedUpCompose(t *testing.T) {
+	cfg := baseLayout()
+	cfg.BallSlowDuration = 1
+	cfg.BallSlowMultiplier = 0.5
+	cfg.BallSpeedUpDuration = 1
+	cfg.BallSpeedUpMultiplier = 1.5
+	state := newLaunchedState(cfg, []Block{})
+
+	StartEffect(state, cfg, EffectBallSlow)
+	StartEffect(state, cfg, EffectBallSpeedUp)
+
+	b := state.Balls[0]
+	if got, want := math.Hypot(b.VX, b.VY), cfg.BallSpeed*0.75; math.Abs(got-want) > 1e-9 {
+		t.Fatalf("expected speed %v, got %v", want, got)
+	}
+	if b.Speed != cfg.BallSpeed {
+		t.Fatalf("expected the base speed kept, got %v", b.Speed)
+	}
+}
+
+func TestMultiballSplitUnderSlowKeepsBaseSpeeds(t *testing.T) {
+	cfg := baseLayout()
+	cfg.MaxBalls = 4
+	cfg.BallSlowDuration = 1.0 / 60
+	cfg.BallSlowMultiplier = 0.5
+	state := newLaunchedState(cfg, []Block{})
+
+	StartEffect(state, cfg, EffectBallSlow)
+	applyMultiball(state, cfg)
+	applyMultiball(state, cfg)
+	if len(state.Balls) != 4 {
+		t.Fatalf("expected 4 balls, got %d", len(state.Balls))
+	}
+	for i, b := range state.Balls {
+		if b.Speed != cfg.BallSpeed {
+			t.Fatalf("expected ball %d to keep the base speed %v, got %v", i, cfg.BallSpeed, b.Speed)
+		}
+		if got := math.Hypot(b.VX, b.VY); math.Abs(got-cfg.BallSpeed/2) > 1e-9 {
+			t.Fatalf("expected ball %d slowed to %v, got %v", i, cfg.BallSpeed/2, got)
+		}
+	}
+
+	updateEffects(state)
+	for i, b := range state.Balls {
+		if got := math.Hypot(b.VX, b.VY); math.Abs(got-cfg.BallSpeed) > 1e-9 {
+			t.Fatalf("expected ball %d back at %v, got %v", i, cfg.BallSpeed, got)
+		}
+	}
+}
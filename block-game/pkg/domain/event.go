@@ -0,0 +1,104 @@
+package domain
+
+// Event is something that happened during a step. Advance appends events to
+// GameState.Events in the order they occur; consumers switch on the concrete type.
+type Event interface {
+	event()
+}
+
+// BlockHit is a hit that damaged a block without destroying it.
+type BlockHit struct {
+	Block int // index into GameState.Blocks
+	HP    int // hits left
+}
+
+// BlockDestroyed is a block removed by a hit or an explosion.
+type BlockDestroyed struct {
+	Block int
+	Type  BlockType
+	X, Y  float64 // centre of the block
+}
+
+// ScoreChanged follows every change of GameState.Score.
+type ScoreChanged struct {
+	Delta int
+	Score int // score after the change
+}
+
+// ItemSpawned is an item dropped by a destroyed block.
+type ItemSpawned struct {
+	Type ItemType
+	X, Y float64 // top-left corner of the item
+}
+
+// ItemCollected is an item caught by the paddle. The events of its effect follow it.
+type ItemCollected struct {
+	Type ItemType
+}
+
+// EffectStarted is a timed effect that began, or was refreshed while active.
+type EffectStarted struct {
+	Effect    EffectKind
+	Refreshed bool
+	Ticks     int // duration in fixed steps
+}
+
+// EffectExpired is a timed effect that ran out or was cancelled by losing a life.
+type EffectExpired struct {
+	Effect EffectKind
+}
+
+// LaserFired is a pair of projectiles shot from the paddle edges.
+type LaserFired struct {
+	X float64 // centre of the paddle
+}
+
+// BallCaught is a ball that stuck to the paddle while the catch effect is active.
+type BallCaught struct {
+	X float64 // where it stuck
+}
+
+// BallLost is a ball that fell below the screen.
+type BallLost struct {
+	X float64 // where it left the screen
+}
+
+// LifeLost is a life used up after the last ball fell.
+type LifeLost struct {
+	Lives int // lives left
+}
+
+// LifeGained is a life added by an extra-life item.
+type LifeGained struct {
+	Lives int // lives now
+}
+
+// BarrierHit is the barrier bouncing a ball back up and disappearing.
+type BarrierHit struct {
+	X float64 // where the ball hit it
+}
+
+// GameOver ends the game, by clearing the stage or running out of lives.
+type GameOver struct {
+	Cleared bool
+}
+
+func (BlockHit) event()       {}
+func (BlockDestroyed) event() {}
+func (ScoreChanged) event()   {}
+func (ItemSpawned) event()    {}
+func (ItemCollected) event()  {}
+func (EffectStarted) event()  {}
+func (EffectExpired) event()  {}
+func (LaserFired) event()     {}
+func (BallCaught) event()     {}
+func (BallLost) event()       {}
+func (LifeLost) event()       {}
+func (LifeGained) event()     {}
+func (BarrierHit) event()     {}
+func (GameOver) event()       {}
+
+// emit records an event of the current step.
+func (s *GameState) emit(e Event) {
+	s.Events = append(s.Events, e)
+}
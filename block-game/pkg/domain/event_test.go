@@ -0,0 +1,113 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+// aimAtBlock places the state's only ball just below block b, moving straight up.
+func aimAtBlock(state *GameState, cfg LayoutConfig, b Block) {
+	ball := &state.Balls[0]
+	ball.X, ball.Y = b.X+b.W/2, b.Y+b.H+ball.Radius+1
+	ball.VX, ball.VY = 0, -cfg.BallSpeed
+}
+
+func assertEvents(t *testing.T, got, want []Event) {
+	t.Helper()
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("events:\n got %#v\nwant %#v", got, want)
+	}
+}
+
+func TestAdvanceEmitsBlockEvents(t *testing.T) {
+	tests := []struct {
+		name   string
+		drops  DropTable
+		blocks []Block
+		want   []Event
+	}{
+		{
+			name:   "hard block damaged",
+			blocks: []Block{NewBlock(100, 100, 70, 30, BlockTypeHard)},
+			want:   []Event{BlockHit{Block: 0, HP: 2}},
+		},
+		{
+			name:  "item block drops an item",
+			drops: DropTable{Weights: map[ItemType]float64{ItemTypeMultiball: 1}},
+			blocks: []Block{
+				NewBlock(100, 100, 70, 30, BlockTypeItem),
+				NewBlock(600, 10, 70, 30, BlockTypeHard),
+			},
+			want: []Event{
+				BlockDestroyed{Block: 0, Type: BlockTypeItem, X: 135, Y: 115},
+				ScoreChanged{Delta: 2, Score: 2},
+				ItemSpawned{Type: ItemTypeMultiball, X: 127, Y: 109},
+			},
+		},
+		{
+			name: "explosion chain clears the stage",
+			blocks: []Block{
+				NewBlock(100, 100, 70, 30, BlockTypeExplosive),
+				NewBlock(175, 100, 70, 30, BlockTypeNormal),
+			},
+			want: []Event{
+				BlockDestroyed{Block: 0, Type: BlockTypeExplosive, X: 135, Y: 115},
+				ScoreChanged{Delta: 2, Score: 2},
+				BlockDestroyed{Block: 1, Type: BlockTypeNormal, X: 210, Y: 115},
+				ScoreChanged{Delta: 1, Score: 3},
+				GameOver{Cleared: true},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := baseLayout()
+			cfg.Drops = tt.drops
+			state := newLaunchedState(cfg, tt.blocks)
+			aimAtBlock(state, cfg, tt.blocks[0])
+
+			Advance(state, InputState{}, cfg, NewRandomSource(nil))
+			assertEvents(t, state.Events, tt.want)
+		})
+	}
+}
+
+func TestAdvanceEmitsLastLifeEvents(t *testing.T) {
+	cfg := baseLayout()
+	state := newLaunchedState(cfg, []Block{NewBlock(100, 100, 70, 30, BlockTypeNormal)})
+	state.Lives = 1
+	ball := &state.Balls[0]
+	ball.X, ball.Y = 400, cfg.ScreenH-ball.Radius
+	ball.VX, ball.VY = 0, cfg.BallSpeed
+
+	Advance(state, InputState{}, cfg, NewRandomSource(nil))
+	assertEvents(t, state.Events, []Event{
+		BallLost{X: 400},
+		LifeLost{Lives: 0},
+		GameOver{},
+	})
+}
+
+func TestAdvanceEmitsEffectEventsPerTick(t *testing.T) {
+	cfg := baseLayout()
+	cfg.PaddleEnlargeMultiplier = 2
+	cfg.PaddleEnlargeDuration = 3.0 / 60
+	state := newLaunchedState(cfg, []Block{})
+	p := state.Paddle
+	state.Items = []Item{{X: p.X + 10, Y: p.Y - 5, Width: 16, Height: 12, Active: true, Type: ItemTypePaddleEnlarge}}
+
+	var perTick [][]Event
+	for i := 0; i < 4; i++ {
+		Advance(state, InputState{}, cfg, NewRandomSource(nil))
+		perTick = append(perTick, append([]Event(nil), state.Events...))
+	}
+	want := [][]Event{
+		{ItemCollected{Type: ItemTypePaddleEnlarge}, EffectStarted{Effect: EffectPaddleEnlarge, Ticks: 3}},
+		nil,
+		{EffectExpired{Effect: EffectPaddleEnlarge}},
+		nil,
+	}
+	if !reflect.DeepEqual(perTick, want) {
+		t.Fatalf("events per tick:\n got %#v\nwant %#v", perTick, want)
+	}
+}
@@ -1,217 +1,182 @@
 package domain
 
-import "math"
-
 var ballService = NewBallService()
 
 type Paddle struct {
-	X, Y   float64
-	Width  float64
-	Height float64
+	X, Y      float64
+	Width     float64
+	Height    float64
+	BaseWidth float64 // width without effects; Width is derived from it (see Modifier)
 }
 
-// ItemType represents the type of a falling item.
-type ItemType int
-
-const (
-	ItemTypeMultiball ItemType = iota
-	ItemTypePaddleEnlarge
-)
-
-type Item struct {
-	X, Y   float64
-	Width  float64
-	Height float64
-	VY     float64
-	Active bool
-	Type   ItemType
+type GameState struct {
+	Blocks   []Block
+	Balls    []Ball
+	Paddle   Paddle
+	Items    []Item
+	Effects  []ActiveEffect // timed effects in force, in the order they started
+	Score    int
+	Lives    int
+	GameOver bool
+	Cleared  bool // every destructible block was destroyed; set together with GameOver
+	Tick     int  // number of fixed steps simulated so far
+	Stats    Stats
+	Contacts []Contact `json:"-"` // collisions resolved in the latest step, for debugging; not saved
+	Events   []Event   `json:"-"` // what happened in the latest step, in order; not saved
+
+	Projectiles  []Projectile // laser shots in flight
+	FireCooldown int          // steps until the laser can fire again
+
+	Barrier Barrier // one-time floor below the paddle
+
+	blockGrid *BlockGrid // broad-phase index over Blocks, built lazily
 }
 
-// PaddleEffect tracks the temporary paddle enlargement state.
-type PaddleEffect struct {
-	Active         bool
-	RemainingTicks int     // 60FPS: 5 seconds = 300 ticks
-	BaseWidth      float64 // original paddle width before effect
-	Multiplier     float64 // e.g., 3.0
+// Clone returns a copy of s that shares no slices with it. Empty slices stay empty
+// rather than nil, so the copy compares equal to s.
+func (s *GameState) Clone() GameState {
+	var c GameState
+	s.CloneInto(&c)
+	return c
 }
 
-type GameState struct {
-	Blocks       []Block
-	Balls        []Ball
-	Paddle       Paddle
-	Items        []Item
-	PaddleEffect PaddleEffect
-	Score        int
-	GameOver     bool
+// CloneInto makes dst a copy of s like Clone does, reusing dst's slice buffers.
+// The broad-phase index is never shared; dst rebuilds its own when needed.
+func (s *GameState) CloneInto(dst *GameState) {
+	blocks, balls, items, effects := dst.Blocks, dst.Balls, dst.Items, dst.Effects
+	contacts, events, projectiles := dst.Contacts, dst.Events, dst.Projectiles
+	*dst = *s
+	dst.Blocks = copyInto(blocks, s.Blocks)
+	dst.Balls = copyInto(balls, s.Balls)
+	dst.Items = copyInto(items, s.Items)
+	dst.Effects = copyInto(effects, s.Effects)
+	dst.Contacts = copyInto(contacts, s.Contacts)
+	dst.Events = copyInto(events, s.Events)
+	dst.Projectiles = copyInto(projectiles, s.Projectiles)
+	dst.blockGrid = nil
+}
+
+// copyInto copies src into dst's buffer. Like src, the result is nil only when src is nil.
+func copyInto[T any](dst, src []T) []T {
+	if src == nil {
+		return nil
+	}
+	if dst == nil {
+		dst = src[:0:0]
+	}
+	return append(dst[:0], src...)
+}
+
+// BlockGrid returns the broad-phase index over Blocks, rebuilding it if Blocks was replaced.
+func (s *GameState) BlockGrid(cfg LayoutConfig) *BlockGrid {
+	if s.blockGrid == nil || !s.blockGrid.indexes(s.Blocks, cfg) {
+		s.blockGrid = NewBlockGrid(s.Blocks, cfg)
+	}
+	return s.blockGrid
+}
+
+// DestroyBlock marks block i dead and drops it from the broad-phase index.
+func (s *GameState) DestroyBlock(i int, cfg LayoutConfig) {
+	grid := s.BlockGrid(cfg)
+	s.Blocks[i].Alive = false
+	grid.Remove(i, s.Blocks[i])
+}
+
+// MoveBlock repositions block i and keeps the broad-phase index in sync.
+func (s *GameState) MoveBlock(i int, x, y float64, cfg LayoutConfig) {
+	grid := s.BlockGrid(cfg)
+	old := s.Blocks[i]
+	s.Blocks[i].X = x
+	s.Blocks[i].Y = y
+	grid.Move(i, old, s.Blocks[i])
+}
+
+// AddScore adds points to Score and reports the change with a ScoreChanged event.
+func (s *GameState) AddScore(points int) {
+	if points == 0 {
+		return
+	}
+	s.Score += points
+	s.emit(ScoreChanged{Delta: points, Score: s.Score})
+}
+
+// Stats counts what happened during a game, for result summaries.
+type Stats struct {
+	BallsLost       int // balls that fell off the bottom
+	LivesLost       int
+	ItemsDropped    int
+	ItemsCollected  int
+	BlocksDestroyed int
 }
 
 type InputState struct {
 	MoveLeft  bool
 	MoveRight bool
+	Launch    bool // releases balls resting on the paddle
+	Fire      bool // shoots while the laser is active
 }
 
+// NewGameState creates a game with the ball served on the paddle, waiting for launch.
 func NewGameState(cfg LayoutConfig, blocks []Block) *GameState {
-	initialBall := Ball{
-		X:      cfg.ScreenW / 2,
-		Y:      cfg.ScreenH / 2,
-		Radius: cfg.BallRadius,
-		VX:     cfg.BallSpeed * math.Cos(math.Pi/4),
-		VY:     -cfg.BallSpeed * math.Sin(math.Pi/4),
+	paddle := Paddle{
+		X:         (cfg.ScreenW - cfg.PaddleWidth) / 2,
+		Y:         cfg.PaddleY,
+		Width:     cfg.PaddleWidth,
+		Height:    cfg.PaddleHeight,
+		BaseWidth: cfg.PaddleWidth,
+	}
+	lives := cfg.Lives
+	if lives < 1 {
+		lives = 1
 	}
 	return &GameState{
-		Blocks: blocks,
-		Balls:  []Ball{initialBall},
-		Paddle: Paddle{
-			X:      (cfg.ScreenW - cfg.PaddleWidth) / 2,
-			Y:      cfg.PaddleY,
-			Width:  cfg.PaddleWidth,
-			Height: cfg.PaddleHeight,
-		},
+		Blocks:   blocks,
+		Balls:    []Ball{newServeBall(paddle, cfg)},
+		Paddle:   paddle,
 		Items:    []Item{},
 		Score:    0,
+		Lives:    lives,
 		GameOver: false,
 	}
 }
 
+// Advance simulates one fixed step of cfg.StepDuration().
+// Speeds in cfg and on entities are in px/s; callers feed wall-clock time through FixedStep.
 func Advance(state *GameState, input InputState, cfg LayoutConfig, rnd RandomSource) {
 	if state.GameOver {
 		return
 	}
+	state.Tick++
+	state.Contacts = state.Contacts[:0]
+	state.Events = state.Events[:0]
+	dt := cfg.StepSeconds()
 
+	if _, ok := state.Effect(EffectReverseControls); ok {
+		input.MoveLeft, input.MoveRight = input.MoveRight, input.MoveLeft
+	}
 	if input.MoveLeft && state.Paddle.X > 0 {
-		state.Paddle.X -= cfg.PaddleSpeed
+		state.Paddle.X -= cfg.PaddleSpeed * dt
 	}
 	if input.MoveRight && state.Paddle.X < cfg.ScreenW-state.Paddle.Width {
-		state.Paddle.X += cfg.PaddleSpeed
+		state.Paddle.X += cfg.PaddleSpeed * dt
 	}
 
+	updateAttachedBalls(state, input, cfg)
 	updateItems(state, cfg)
-	updatePaddleEffect(state)
+	updateEffects(state)
+	updateLaser(state, input, cfg)
+	updateProjectiles(state, cfg, rnd)
 
 	ballService.Advance(state, cfg, rnd)
 
 	if len(state.Balls) == 0 {
-		state.GameOver = true
+		loseLife(state, cfg)
 		return
 	}
 
-	allBlocksDestroyed := true
-	for _, block := range state.Blocks {
-		if block.Alive {
-			allBlocksDestroyed = false
-			break
-		}
-	}
-	if allBlocksDestroyed && len(state.Blocks) > 0 {
+	if state.BlocksCleared() {
 		state.GameOver = true
-	}
-}
-
-func updateItems(state *GameState, cfg LayoutConfig) {
-	active := state.Items[:0]
-	for _, item := range state.Items {
-		if !item.Active {
-			continue
-		}
-		item.Y += item.VY
-
-		if rectsOverlap(item.X, item.Y, item.Width, item.Height, state.Paddle.X, state.Paddle.Y, state.Paddle.Width, state.Paddle.Height) {
-			// Apply effect based on item type
-			switch item.Type {
-			case ItemTypeMultiball:
-				applyMultiball(state, cfg)
-			case ItemTypePaddleEnlarge:
-				applyPaddleEnlarge(state, cfg)
-			}
-			item.Active = false
-		} else if item.Y > cfg.ScreenH {
-			item.Active = false
-		}
-
-		if item.Active {
-			active = append(active, item)
-		}
-	}
-	state.Items = active
-}
-
-func tryDropItem(state *GameState, cfg LayoutConfig, block *Block, rnd RandomSource) {
-	// Multiball item lottery (independent)
-	if len(state.Items) < cfg.MaxItems && rnd.Float64() < cfg.ItemDropChance {
-		spawnItem(state, cfg, block, ItemTypeMultiball)
-	}
-	// Paddle-enlarge item lottery (independent)
-	if len(state.Items) < cfg.MaxItems && rnd.Float64() < cfg.PaddleEnlargeChance {
-		spawnItem(state, cfg, block, ItemTypePaddleEnlarge)
-	}
-}
-
-// spawnItem creates a new item of the given type at the block's position.
-func spawnItem(state *GameState, cfg LayoutConfig, block *Block, itemType ItemType) {
-	state.Items = append(state.Items, Item{
-		X:      block.X + cfg.BlockW/2 - cfg.ItemWidth/2,
-		Y:      block.Y + cfg.BlockH/2 - cfg.ItemHeight/2,
-		Width:  cfg.ItemWidth,
-		Height: cfg.ItemHeight,
-		VY:     cfg.ItemFallSpeed,
-		Active: true,
-		Type:   itemType,
-	})
-}
-
-func applyMultiball(state *GameState, cfg LayoutConfig) {
-	if len(state.Balls) == 0 {
-		return
-	}
-
-	target := len(state.Balls) * 2
-	if target > cfg.MaxBalls {
-		target = cfg.MaxBalls
-	}
-	if target <= len(state.Balls) {
-		return
-	}
-
-	newBalls := make([]Ball, 0, target)
-	newBalls = append(newBalls, state.Balls...)
-
-	for _, b := range state.Balls {
-		if len(newBalls) >= target {
-			break
-		}
-		dup := b
-		dup.VX = -dup.VX
-		newBalls = append(newBalls, dup)
-	}
-
-	state.Balls = newBalls
-}
-
-// applyPaddleEnlarge activates the paddle enlargement effect.
-// If already active, it resets the duration timer.
-func applyPaddleEnlarge(state *GameState, cfg LayoutConfig) {
-	effect := &state.PaddleEffect
-	if !effect.Active {
-		// First activation: save base width and enlarge
-		effect.BaseWidth = state.Paddle.Width
-		effect.Multiplier = cfg.PaddleEnlargeMultiplier
-		state.Paddle.Width = effect.BaseWidth * effect.Multiplier
-	}
-	// (Re)set timer
-	effect.Active = true
-	effect.RemainingTicks = cfg.PaddleEnlargeDuration
-}
-
-// updatePaddleEffect decrements the effect timer and reverts paddle width when expired.
-func updatePaddleEffect(state *GameState) {
-	effect := &state.PaddleEffect
-	if !effect.Active {
-		return
-	}
-	effect.RemainingTicks--
-	if effect.RemainingTicks <= 0 {
-		state.Paddle.Width = effect.BaseWidth
-		effect.Active = false
-		effect.RemainingTicks = 0
+		state.Cleared = true
+		state.emit(GameOver{Cleared: true})
 	}
 }
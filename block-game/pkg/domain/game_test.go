@@ -1,6 +1,16 @@
 package domain
 
-import "testing"
+import (
+	"reflect"
+	"testing"
+)
+
+// newLaunchedState は発射済みのボールを 1 つ持つ状態を返す
+func newLaunchedState(cfg LayoutConfig, blocks []Block) *GameState {
+	state := NewGameState(cfg, blocks)
+	launchBall(&state.Balls[0], launchAngle(state.Paddle, cfg), cfg.BallSpeed, 1)
+	return state
+}
 
 func TestAdvancePaddleMovement(t *testing.T) {
 	cfg := baseLayout()
@@ -22,7 +32,7 @@ func TestAdvancePaddleMovement(t *testing.T) {
 
 func TestAdvanceBallBouncesOnWall(t *testing.T) {
 	cfg := baseLayout()
-	state := NewGameState(cfg, []Block{})
+	state := newLaunchedState(cfg, []Block{})
 	state.Balls[0].X = state.Balls[0].Radius - 1
 	state.Balls[0].VX = -cfg.BallSpeed
 
@@ -34,8 +44,8 @@ func TestAdvanceBallBouncesOnWall(t *testing.T) {
 
 func TestAdvanceDestroysBlock(t *testing.T) {
 	cfg := baseLayout()
-	block := Block{X: 100, Y: 100, Alive: true}
-	state := NewGameState(cfg, []Block{block})
+	block := Block{X: 100, Y: 100, W: 70, H: 30, Alive: true}
+	state := newLaunchedState(cfg, []Block{block})
 
 	state.Balls[0].X = block.X + cfg.BlockW/2
 	state.Balls[0].Y = block.Y - state.Balls[0].Radius - 1
@@ -57,21 +67,38 @@ func TestAdvanceDestroysBlock(t *testing.T) {
 
 func TestAdvanceGameOverWhenBallFalls(t *testing.T) {
 	cfg := baseLayout()
-	state := NewGameState(cfg, []Block{})
+	state := newLaunchedState(cfg, []Block{})
 	state.Balls[0].Y = cfg.ScreenH + state.Balls[0].Radius + 1
 
 	Advance(state, InputState{}, cfg, NewRandomSource(nil))
 	if !state.GameOver {
 		t.Fatalf("expected game over when ball falls below screen")
 	}
+	if state.Stats.BallsLost != 1 {
+		t.Fatalf("expected one ball lost, got %+v", state.Stats)
+	}
+}
+
+func TestAdvanceClearedWhenLastBlockDestroyed(t *testing.T) {
+	cfg := baseLayout()
+	state := newLaunchedState(cfg, []Block{
+		NewBlock(100, 100, 70, 30, BlockTypeNormal),
+		NewBlock(200, 100, 70, 30, BlockTypeIndestructible),
+	})
+	state.DestroyBlock(0, cfg)
+
+	Advance(state, InputState{}, cfg, NewRandomSource(nil))
+	if !state.GameOver || !state.Cleared {
+		t.Fatalf("expected cleared game over, got GameOver=%v Cleared=%v", state.GameOver, state.Cleared)
+	}
 }
 
 func TestItemDropAndPickupTriggersMultiball(t *testing.T) {
 	cfg := baseLayout()
-	cfg.ItemDropChance = 1.0
-	state := NewGameState(cfg, []Block{
-		{X: 100, Y: 100, Alive: true},
-		{X: 200, Y: 100, Alive: true},
+	cfg.Drops = DropTable{Chance: 1, Weights: map[ItemType]float64{ItemTypeMultiball: 1}}
+	state := newLaunchedState(cfg, []Block{
+		{X: 100, Y: 100, W: 70, H: 30, Alive: true},
+		{X: 200, Y: 100, W: 70, H: 30, Alive: true},
 	})
 
 	// position ball to hit the block
@@ -95,128 +122,84 @@ func TestItemDropAndPickupTriggersMultiball(t *testing.T) {
 	if len(state.Balls) < 2 {
 		t.Fatalf("expected balls to increase after pickup, got %d", len(state.Balls))
 	}
-}
-
-// --- Paddle-enlarge item tests ---
-
-func TestTryDropItemPaddleEnlargeIndependent(t *testing.T) {
-	cfg := baseLayout()
-	cfg.ItemDropChance = 0.0      // no multiball
-	cfg.PaddleEnlargeChance = 1.0 // always paddle-enlarge
-	block := Block{X: 100, Y: 100, Alive: true}
-	state := NewGameState(cfg, []Block{block})
-
-	rnd := NewRandomSource(nil)
-	tryDropItem(state, cfg, &block, rnd)
-
-	if len(state.Items) != 1 {
-		t.Fatalf("expected 1 item, got %d", len(state.Items))
-	}
-	if state.Items[0].Type != ItemTypePaddleEnlarge {
-		t.Fatalf("expected paddle-enlarge item, got %v", state.Items[0].Type)
+	if state.Stats.BlocksDestroyed != 1 || state.Stats.ItemsDropped != 1 || state.Stats.ItemsCollected != 1 {
+		t.Fatalf("unexpected stats: %+v", state.Stats)
 	}
 }
 
-func TestTryDropItemBothTypesCanSpawn(t *testing.T) {
-	cfg := baseLayout()
-	cfg.ItemDropChance = 1.0
-	cfg.PaddleEnlargeChance = 1.0
-	cfg.MaxItems = 10
-	block := Block{X: 100, Y: 100, Alive: true}
-	state := NewGameState(cfg, []Block{block})
-
-	rnd := NewRandomSource(nil)
-	tryDropItem(state, cfg, &block, rnd)
-
-	if len(state.Items) != 2 {
-		t.Fatalf("expected 2 items (both types), got %d", len(state.Items))
-	}
-
-	hasMultiball := false
-	hasPaddleEnlarge := false
-	for _, item := range state.Items {
-		if item.Type == ItemTypeMultiball {
-			hasMultiball = true
-		}
-		if item.Type == ItemTypePaddleEnlarge {
-			hasPaddleEnlarge = true
-		}
-	}
-	if !hasMultiball || !hasPaddleEnlarge {
-		t.Fatalf("expected both item types, multiball=%v paddleEnlarge=%v", hasMultiball, hasPaddleEnlarge)
-	}
-}
+// --- Paddle-enlarge item tests ---
 
-func TestApplyPaddleEnlarge(t *testing.T) {
+func TestStartPaddleEnlarge(t *testing.T) {
 	cfg := baseLayout()
-	cfg.PaddleEnlargeDuration = 300
+	cfg.PaddleEnlargeDuration = 5
 	cfg.PaddleEnlargeMultiplier = 3.0
 	state := NewGameState(cfg, []Block{})
 	originalWidth := state.Paddle.Width
 
-	applyPaddleEnlarge(state, cfg)
+	StartEffect(state, cfg, EffectPaddleEnlarge)
 
-	if !state.PaddleEffect.Active {
-		t.Fatal("expected PaddleEffect.Active to be true")
+	effect, ok := state.Effect(EffectPaddleEnlarge)
+	if !ok {
+		t.Fatal("expected the paddle enlarge effect to be active")
 	}
-	if state.PaddleEffect.RemainingTicks != 300 {
-		t.Fatalf("expected RemainingTicks=300, got %d", state.PaddleEffect.RemainingTicks)
+	if effect.RemainingTicks != 300 {
+		t.Fatalf("expected RemainingTicks=300, got %d", effect.RemainingTicks)
 	}
-	if state.PaddleEffect.BaseWidth != originalWidth {
-		t.Fatalf("expected BaseWidth=%v, got %v", originalWidth, state.PaddleEffect.BaseWidth)
+	if state.Paddle.BaseWidth != originalWidth {
+		t.Fatalf("expected BaseWidth=%v, got %v", originalWidth, state.Paddle.BaseWidth)
 	}
 	if state.Paddle.Width != originalWidth*3.0 {
 		t.Fatalf("expected paddle width=%v, got %v", originalWidth*3.0, state.Paddle.Width)
 	}
 }
 
-func TestApplyPaddleEnlargeRePickupResetsTimer(t *testing.T) {
+func TestStartPaddleEnlargeRePickupResetsTimer(t *testing.T) {
 	cfg := baseLayout()
-	cfg.PaddleEnlargeDuration = 300
+	cfg.PaddleEnlargeDuration = 5
 	cfg.PaddleEnlargeMultiplier = 3.0
 	state := NewGameState(cfg, []Block{})
 
-	applyPaddleEnlarge(state, cfg)
+	StartEffect(state, cfg, EffectPaddleEnlarge)
 	enlargedWidth := state.Paddle.Width
 
 	// Simulate some time passing
 	for i := 0; i < 100; i++ {
-		updatePaddleEffect(state)
+		updateEffects(state)
 	}
-	if state.PaddleEffect.RemainingTicks != 200 {
-		t.Fatalf("expected RemainingTicks=200 after 100 ticks, got %d", state.PaddleEffect.RemainingTicks)
+	if effect, _ := state.Effect(EffectPaddleEnlarge); effect.RemainingTicks != 200 {
+		t.Fatalf("expected RemainingTicks=200 after 100 ticks, got %d", effect.RemainingTicks)
 	}
 
 	// Re-pickup
-	applyPaddleEnlarge(state, cfg)
+	StartEffect(state, cfg, EffectPaddleEnlarge)
 
-	if state.PaddleEffect.RemainingTicks != 300 {
-		t.Fatalf("expected RemainingTicks reset to 300, got %d", state.PaddleEffect.RemainingTicks)
+	if effect, _ := state.Effect(EffectPaddleEnlarge); effect.RemainingTicks != 300 {
+		t.Fatalf("expected RemainingTicks reset to 300, got %d", effect.RemainingTicks)
 	}
 	if state.Paddle.Width != enlargedWidth {
 		t.Fatalf("expected paddle width unchanged at %v, got %v", enlargedWidth, state.Paddle.Width)
 	}
 }
 
-func TestUpdatePaddleEffectCountdown(t *testing.T) {
+func TestUpdateEffectsCountdown(t *testing.T) {
 	cfg := baseLayout()
-	cfg.PaddleEnlargeDuration = 10
+	cfg.PaddleEnlargeDuration = 10 * cfg.StepSeconds()
 	cfg.PaddleEnlargeMultiplier = 3.0
 	state := NewGameState(cfg, []Block{})
 	originalWidth := state.Paddle.Width
 
-	applyPaddleEnlarge(state, cfg)
+	StartEffect(state, cfg, EffectPaddleEnlarge)
 
 	// Count down to 0
 	for i := 0; i < 10; i++ {
-		if !state.PaddleEffect.Active {
+		if _, ok := state.Effect(EffectPaddleEnlarge); !ok {
 			t.Fatalf("effect should still be active at tick %d", i)
 		}
-		updatePaddleEffect(state)
+		updateEffects(state)
 	}
 
-	if state.PaddleEffect.Active {
-		t.Fatal("expected PaddleEffect.Active to be false after countdown")
+	if len(state.Effects) != 0 {
+		t.Fatal("expected no active effect after countdown")
 	}
 	if state.Paddle.Width != originalWidth {
 		t.Fatalf("expected paddle width reverted to %v, got %v", originalWidth, state.Paddle.Width)
@@ -225,13 +208,12 @@ func TestUpdatePaddleEffectCountdown(t *testing.T) {
 
 func TestPaddleEnlargeItemPickupFlow(t *testing.T) {
 	cfg := baseLayout()
-	cfg.ItemDropChance = 0.0
-	cfg.PaddleEnlargeChance = 1.0
-	cfg.PaddleEnlargeDuration = 5
+	cfg.Drops = DropTable{Chance: 1, Weights: map[ItemType]float64{ItemTypePaddleEnlarge: 1}}
+	cfg.PaddleEnlargeDuration = 5 * cfg.StepSeconds()
 	cfg.PaddleEnlargeMultiplier = 3.0
 
-	block := Block{X: 100, Y: 100, Alive: true}
-	state := NewGameState(cfg, []Block{block})
+	block := Block{X: 100, Y: 100, W: 70, H: 30, Alive: true}
+	state := newLaunchedState(cfg, []Block{block})
 	originalWidth := state.Paddle.Width
 
 	// Position ball to hit block
@@ -250,10 +232,10 @@ func TestPaddleEnlargeItemPickupFlow(t *testing.T) {
 		t.Fatalf("expected paddle-enlarge type, got %v", state.Items[0].Type)
 	}
 
-	// Directly call applyPaddleEnlarge to simulate pickup (updateItems handles collision)
-	applyPaddleEnlarge(state, cfg)
+	// Directly start the effect to simulate pickup (updateItems handles collision)
+	StartEffect(state, cfg, EffectPaddleEnlarge)
 
-	if !state.PaddleEffect.Active {
+	if _, ok := state.Effect(EffectPaddleEnlarge); !ok {
 		t.Fatal("expected effect to be active after pickup")
 	}
 	if state.Paddle.Width != originalWidth*3.0 {
@@ -262,13 +244,35 @@ func TestPaddleEnlargeItemPickupFlow(t *testing.T) {
 
 	// Wait for effect to expire
 	for i := 0; i < 10; i++ {
-		updatePaddleEffect(state)
+		updateEffects(state)
 	}
 
-	if state.PaddleEffect.Active {
+	if _, ok := state.Effect(EffectPaddleEnlarge); ok {
 		t.Fatal("expected effect to expire")
 	}
 	if state.Paddle.Width != originalWidth {
 		t.Fatalf("expected width reverted to %v, got %v", originalWidth, state.Paddle.Width)
 	}
 }
+
+func TestCloneSharesNothing(t *testing.T) {
+	cfg := baseLayout()
+	state := newLaunchedState(cfg, []Block{NewBlock(100, 100, 70, 30, BlockTypeNormal)})
+	state.BlockGrid(cfg)
+	state.AddScore(10)
+
+	c := state.Clone()
+	if c.blockGrid != nil {
+		t.Fatalf("the broad-phase index must not be shared")
+	}
+	c.blockGrid = state.blockGrid
+	if !reflect.DeepEqual(c, *state) {
+		t.Fatalf("clone differs:\n got %+v\nwant %+v", c, *state)
+	}
+	c.Blocks[0].Alive = false
+	c.Balls[0].X++
+	c.Events[0] = GameOver{}
+	if !state.Blocks[0].Alive || state.Balls[0].X == c.Balls[0].X || state.Events[0] == c.Events[0] {
+		t.Fatalf("clone shares slices with the original")
+	}
+}
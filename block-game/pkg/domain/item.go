@@ -0,0 +1,330 @@
+package domain
+
+// ItemType represents the type of a falling item.
+type ItemType int
+
+const (
+	ItemTypeMultiball ItemType = iota
+	ItemTypePaddleEnlarge
+	ItemTypeLaser
+	ItemTypeCatch
+	ItemTypeFireball
+	ItemTypePaddleShrink
+	ItemTypeBallSpeedUp
+	ItemTypeReverseControls
+	ItemTypeExtraLife
+	ItemTypeBarrier
+	ItemTypeBallSlow
+)
+
+// itemTypeOrder fixes the iteration order for weighted selection.
+var itemTypeOrder = []ItemType{
+	ItemTypeMultiball,
+	ItemTypePaddleEnlarge,
+	ItemTypeLaser,
+	ItemTypeCatch,
+	ItemTypeFireball,
+	ItemTypePaddleShrink,
+	ItemTypeBallSpeedUp,
+	ItemTypeReverseControls,
+	ItemTypeExtraLife,
+	ItemTypeBarrier,
+	ItemTypeBallSlow,
+}
+
+// ItemTypeSpec describes how an item type drops, falls, looks and acts.
+type ItemTypeSpec struct {
+	Name      string   // identifier used by level files
+	Weight    float64  // default relative drop weight (see DropTable.Weights); unused for harmful items
+	Harmful   bool     // hinders the player; drops only with a weight from LayoutConfig.HazardWeights
+	MaxActive int      // most items of this type falling at once; 0 means only LayoutConfig.MaxItems applies
+	FallScale float64  // multiple of LayoutConfig.ItemFallSpeed; 0 means 1
+	Color     [3]uint8 // RGB the item is drawn in
+
+	// Collect runs when the paddle catches the item.
+	Collect func(state *GameState, cfg LayoutConfig)
+}
+
+var itemTypeSpecs = map[ItemType]ItemTypeSpec{
+	ItemTypeMultiball: {
+		Name:    "multiball",
+		Weight:  5,
+		Color:   [3]uint8{255, 200, 50},
+		Collect: applyMultiball,
+	},
+	ItemTypePaddleEnlarge: {
+		Name:   "paddleEnlarge",
+		Weight: 1,
+		Color:  [3]uint8{50, 255, 100},
+		Collect: func(state *GameState, cfg LayoutConfig) {
+			StartEffect(state, cfg, EffectPaddleEnlarge)
+		},
+	},
+	ItemTypeLaser: {
+		Name:   "laser",
+		Weight: 1,
+		Color:  [3]uint8{255, 80, 80},
+		Collect: func(state *GameState, cfg LayoutConfig) {
+			StartEffect(state, cfg, EffectLaser)
+		},
+	},
+	ItemTypeCatch: {
+		Name:   "catch",
+		Weight: 1,
+		Color:  [3]uint8{190, 110, 255},
+		Collect: func(state *GameState, cfg LayoutConfig) {
+			StartEffect(state, cfg, EffectCatch)
+		},
+	},
+	ItemTypeFireball: {
+		Name:   "fireball",
+		Weight: 1,
+		Color:  [3]uint8{255, 120, 30},
+		Collect: func(state *GameState, cfg LayoutConfig) {
+			StartEffect(state, cfg, EffectFireball)
+		},
+	},
+	ItemTypePaddleShrink: {
+		Name:    "paddleShrink",
+		Harmful: true,
+		Color:   [3]uint8{120, 120, 120},
+		Collect: func(state *GameState, cfg LayoutConfig) {
+			StartEffect(state, cfg, EffectPaddleShrink)
+		},
+	},
+	ItemTypeBallSpeedUp: {
+		Name:    "speedUp",
+		Harmful: true,
+		Color:   [3]uint8{255, 40, 160},
+		Collect: func(state *GameState, cfg LayoutConfig) {
+			StartEffect(state, cfg, EffectBallSpeedUp)
+		},
+	},
+	ItemTypeReverseControls: {
+		Name:    "reverse",
+		Harmful: true,
+		Color:   [3]uint8{60, 60, 200},
+		Collect: func(state *GameState, cfg LayoutConfig) {
+			StartEffect(state, cfg, EffectReverseControls)
+		},
+	},
+	ItemTypeExtraLife: {
+		Name:      "extraLife",
+		Weight:    0.5,
+		MaxActive: 1,
+		Color:     [3]uint8{255, 105, 180},
+		Collect:   gainLife,
+	},
+	ItemTypeBarrier: {
+		Name:    "barrier",
+		Weight:  1,
+		Color:   [3]uint8{80, 200, 255},
+		Collect: raiseBarrier,
+	},
+	ItemTypeBallSlow: {
+		Name:   "slow",
+		Weight: 1,
+		Color:  [3]uint8{150, 230, 255},
+		Collect: func(state *GameState, cfg LayoutConfig) {
+			StartEffect(state, cfg, EffectBallSlow)
+		},
+	},
+}
+
+// ItemSpec returns the spec of the given item type and whether it is registered.
+func ItemSpec(t ItemType) (ItemTypeSpec, bool) {
+	spec, ok := itemTypeSpecs[t]
+	return spec, ok
+}
+
+// ItemTypes returns every registered item type in a fixed order.
+func ItemTypes() []ItemType {
+	return append([]ItemType(nil), itemTypeOrder...)
+}
+
+// ItemTypeNamed returns the item type with the given spec name.
+func ItemTypeNamed(name string) (ItemType, bool) {
+	for _, t := range itemTypeOrder {
+		if itemTypeSpecs[t].Name == name {
+			return t, true
+		}
+	}
+	return 0, false
+}
+
+type Item struct {
+	X, Y   float64
+	Width  float64
+	Height float64
+	VY     float64 // fall speed in px/s
+	Active bool
+	Type   ItemType
+}
+
+// DropTable decides what a destroyed block drops: nothing with probability 1-Chance,
+// otherwise one item picked in proportion to Weights. Harmful items a table does not
+// name are weighted by LayoutConfig.HazardWeights, so the difficulty decides the risk.
+type DropTable struct {
+	Chance  float64              // probability that a block drops an item (0..1)
+	Weights map[ItemType]float64 // relative weights; nil uses each item's spec Weight
+}
+
+// weight returns the table's weight for t, with hazards weighted as in cfg.
+func (d DropTable) weight(t ItemType, cfg LayoutConfig) float64 {
+	if w, ok := d.Weights[t]; ok {
+		return w
+	}
+	spec := itemTypeSpecs[t]
+	switch {
+	case spec.Harmful:
+		return cfg.HazardWeights[t]
+	case d.Weights == nil:
+		return spec.Weight
+	}
+	return 0
+}
+
+// DropTable returns the drop table for blocks of type t: its entry in BlockDrops if
+// there is one, otherwise Drops. Blocks whose spec forces a drop always drop.
+func (cfg LayoutConfig) DropTable(t BlockType) DropTable {
+	table, ok := cfg.BlockDrops[t]
+	if !ok {
+		table = cfg.Drops
+	}
+	if BlockSpec(t).ForceDrop {
+		table.Chance = 1
+	}
+	return table
+}
+
+// dropItem rolls the block's drop table and spawns at most one item. Item types that
+// reached their MaxActive are left out of the choice; nothing drops at MaxItems.
+func dropItem(state *GameState, cfg LayoutConfig, block *Block, rnd RandomSource) {
+	table := cfg.DropTable(block.Type)
+	if table.Chance <= 0 || len(state.Items) >= cfg.MaxItems {
+		return
+	}
+	if table.Chance < 1 && rnd.Float64() >= table.Chance {
+		return
+	}
+
+	total := 0.0
+	for _, t := range itemTypeOrder {
+		if w := table.weight(t, cfg); w > 0 && state.canDrop(t) {
+			total += w
+		}
+	}
+	if total <= 0 {
+		return
+	}
+	r := rnd.Float64() * total
+	for _, t := range itemTypeOrder {
+		w := table.weight(t, cfg)
+		if w <= 0 || !state.canDrop(t) {
+			continue
+		}
+		if r < w {
+			spawnItem(state, cfg, block, t)
+			return
+		}
+		r -= w
+	}
+}
+
+// canDrop reports whether another item of type t may fall.
+func (s *GameState) canDrop(t ItemType) bool {
+	limit := itemTypeSpecs[t].MaxActive
+	if limit <= 0 {
+		return true
+	}
+	n := 0
+	for _, item := range s.Items {
+		if item.Type == t {
+			n++
+		}
+	}
+	return n < limit
+}
+
+// spawnItem creates a new item of the given type at the block's position.
+func spawnItem(state *GameState, cfg LayoutConfig, block *Block, itemType ItemType) {
+	cx, cy := block.Center()
+	speed := cfg.ItemFallSpeed
+	if scale := itemTypeSpecs[itemType].FallScale; scale > 0 {
+		speed *= scale
+	}
+	state.Items = append(state.Items, Item{
+		X:      cx - cfg.ItemWidth/2,
+		Y:      cy - cfg.ItemHeight/2,
+		Width:  cfg.ItemWidth,
+		Height: cfg.ItemHeight,
+		VY:     speed,
+		Active: true,
+		Type:   itemType,
+	})
+	state.Stats.ItemsDropped++
+	state.emit(ItemSpawned{Type: itemType, X: cx - cfg.ItemWidth/2, Y: cy - cfg.ItemHeight/2})
+}
+
+func updateItems(state *GameState, cfg LayoutConfig) {
+	active := state.Items[:0]
+	for _, item := range state.Items {
+		if !item.Active {
+			continue
+		}
+		item.Y += item.VY * cfg.StepSeconds()
+
+		if rectsOverlap(item.X, item.Y, item.Width, item.Height, state.Paddle.X, state.Paddle.Y, state.Paddle.Width, state.Paddle.Height) {
+			state.emit(ItemCollected{Type: item.Type})
+			if spec, ok := ItemSpec(item.Type); ok && spec.Collect != nil {
+				spec.Collect(state, cfg)
+			}
+			item.Active = false
+			state.Stats.ItemsCollected++
+		} else if item.Y > cfg.ScreenH {
+			item.Active = false
+		}
+
+		if item.Active {
+			active = append(active, item)
+		}
+	}
+	state.Items = active
+}
+
+func applyMultiball(state *GameState, cfg LayoutConfig) {
+	if len(state.Balls) == 0 {
+		return
+	}
+
+	target := len(state.Balls) * 2
+	if target > cfg.MaxBalls {
+		target = cfg.MaxBalls
+	}
+	if target <= len(state.Balls) {
+		return
+	}
+
+	newBalls := make([]Ball, 0, target)
+	newBalls = append(newBalls, state.Balls...)
+
+	for _, b := range state.Balls {
+		if len(newBalls) >= target {
+			break
+		}
+		dup := b
+		dup.VX = -dup.VX
+		newBalls = append(newBalls, dup)
+	}
+
+	state.Balls = newBalls
+}
+
+// gainLife adds a life, up to LayoutConfig.MaxLives.
+func gainLife(state *GameState, cfg LayoutConfig) {
+	if cfg.MaxLives > 0 && state.Lives >= cfg.MaxLives {
+		return
+	}
+	state.Lives++
+	state.emit(LifeGained{Lives: state.Lives})
+}
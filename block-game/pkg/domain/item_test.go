@@ -0,0 +1,193 @@
+package domain
+
+import "testing"
+
+// withItem registers a test item type for the duration of the test.
+func withItem(t *testing.T, itemType ItemType, spec ItemTypeSpec) {
+	t.Helper()
+	itemTypeSpecs[itemType] = spec
+	order := itemTypeOrder
+	itemTypeOrder = append(order[:len(order):len(order)], itemType)
+	t.Cleanup(func() {
+		delete(itemTypeSpecs, itemType)
+		itemTypeOrder = order
+	})
+}
+
+func TestDropItemPicksByWeight(t *testing.T) {
+	weights := map[ItemType]float64{ItemTypeMultiball: 3, ItemTypePaddleEnlarge: 1}
+	tests := []struct {
+		name   string
+		table  DropTable
+		floats []float64
+		want   []ItemType
+		draws  int
+	}{
+		{name: "no chance draws nothing", table: DropTable{Weights: weights}, floats: []float64{0}, draws: 0},
+		{name: "failed roll", table: DropTable{Chance: 0.5, Weights: weights}, floats: []float64{0.6}, draws: 1},
+		{name: "first weight", table: DropTable{Chance: 0.5, Weights: weights}, floats: []float64{0.4, 0.7}, want: []ItemType{ItemTypeMultiball}, draws: 2},
+		{name: "second weight", table: DropTable{Chance: 0.5, Weights: weights}, floats: []float64{0.4, 0.8}, want: []ItemType{ItemTypePaddleEnlarge}, draws: 2},
+		{name: "certain drop skips the roll", table: DropTable{Chance: 1, Weights: weights}, floats: []float64{0.8}, want: []ItemType{ItemTypePaddleEnlarge}, draws: 1},
+		{name: "spec weights by default", table: DropTable{Chance: 1}, floats: []float64{0}, want: []ItemType{ItemTypeMultiball}, draws: 1},
+		{name: "no weights", table: DropTable{Chance: 1, Weights: map[ItemType]float64{}}, floats: []float64{0}, draws: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := baseLayout()
+			cfg.Drops = tt.table
+			block := NewBlock(100, 100, 70, 30, BlockTypeNormal)
+			state := NewGameState(cfg, []Block{block})
+			rnd := &mockRandom{floats: tt.floats}
+
+			dropItem(state, cfg, &block, rnd)
+
+			var got []ItemType
+			for _, item := range state.Items {
+				got = append(got, item.Type)
+			}
+			if len(got) != len(tt.want) || (len(got) == 1 && got[0] != tt.want[0]) {
+				t.Fatalf("expected items %v, got %v", tt.want, got)
+			}
+			if rnd.idx != tt.draws {
+				t.Fatalf("expected %d draws, got %d", tt.draws, rnd.idx)
+			}
+		})
+	}
+}
+
+func TestDropTablePerBlockType(t *testing.T) {
+	cfg := baseLayout()
+	cfg.Drops = DropTable{Chance: 0}
+	cfg.BlockDrops = map[BlockType]DropTable{
+		BlockTypeHard: {Chance: 1, Weights: map[ItemType]float64{ItemTypePaddleEnlarge: 1}},
+	}
+
+	if got := cfg.DropTable(BlockTypeNormal); got.Chance != 0 {
+		t.Fatalf("expected normal blocks to use Drops, got %+v", got)
+	}
+	if got := cfg.DropTable(BlockTypeHard); got.Chance != 1 || got.weight(ItemTypePaddleEnlarge, cfg) != 1 || got.weight(ItemTypeMultiball, cfg) != 0 {
+		t.Fatalf("expected the hard block table, got %+v", got)
+	}
+	if got := cfg.DropTable(BlockTypeItem); got.Chance != 1 {
+		t.Fatalf("expected item blocks to always drop, got %+v", got)
+	}
+
+	block := NewBlock(100, 100, 70, 30, BlockTypeHard)
+	state := NewGameState(cfg, []Block{block})
+	dropItem(state, cfg, &block, &mockRandom{floats: []float64{0.5}})
+	if len(state.Items) != 1 || state.Items[0].Type != ItemTypePaddleEnlarge {
+		t.Fatalf("expected a paddle-enlarge item from the hard block, got %+v", state.Items)
+	}
+}
+
+func TestDropItemRespectsLimits(t *testing.T) {
+	const capped ItemType = 100
+	withItem(t, capped, ItemTypeSpec{Name: "capped", Weight: 1, MaxActive: 1})
+
+	cfg := baseLayout()
+	cfg.MaxItems = 3
+	cfg.Drops = DropTable{Chance: 1, Weights: map[ItemType]float64{ItemTypeMultiball: 1, capped: 1}}
+	block := NewBlock(100, 100, 70, 30, BlockTypeNormal)
+	state := NewGameState(cfg, []Block{block})
+	state.Items = []Item{{Active: true, Type: capped}}
+
+	// The capped type is left out, so even the highest roll picks multiball.
+	dropItem(state, cfg, &block, &mockRandom{floats: []float64{0.99}})
+	if len(state.Items) != 2 || state.Items[1].Type != ItemTypeMultiball {
+		t.Fatalf("expected multiball next to the capped item, got %+v", state.Items)
+	}
+
+	state.Items = append(state.Items, Item{Active: true, Type: ItemTypeMultiball})
+	rnd := &mockRandom{floats: []float64{0}}
+	dropItem(state, cfg, &block, rnd)
+	if len(state.Items) != 3 || rnd.idx != 0 {
+		t.Fatalf("expected no drop at MaxItems, got %d items after %d draws", len(state.Items), rnd.idx)
+	}
+}
+
+func TestItemSpecDrivesSpawnAndCollect(t *testing.T) {
+	const custom ItemType = 100
+	collected := 0
+	withItem(t, custom, ItemTypeSpec{
+		Name:      "custom",
+		FallScale: 2,
+		Collect:   func(*GameState, LayoutConfig) { collected++ },
+	})
+
+	cfg := baseLayout()
+	state := NewGameState(cfg, []Block{})
+	block := NewBlock(100, 100, 70, 30, BlockTypeNormal)
+	spawnItem(state, cfg, &block, custom)
+	if state.Items[0].VY != 2*cfg.ItemFallSpeed {
+		t.Fatalf("expected twice the fall speed, got %v", state.Items[0].VY)
+	}
+
+	state.Items[0].X, state.Items[0].Y = state.Paddle.X, state.Paddle.Y
+	updateItems(state, cfg)
+	if collected != 1 || len(state.Items) != 0 || state.Stats.ItemsCollected != 1 {
+		t.Fatalf("expected the item's Collect to run once, got %d (items %d)", collected, len(state.Items))
+	}
+}
+
+func TestItemTypeNamed(t *testing.T) {
+	for _, it := range ItemTypes() {
+		spec, ok := ItemSpec(it)
+		if !ok || spec.Collect == nil || (spec.Weight <= 0 && !spec.Harmful) {
+			t.Fatalf("item type %d has an incomplete spec: %+v", it, spec)
+		}
+		if got, ok := ItemTypeNamed(spec.Name); !ok || got != it {
+			t.Fatalf("expected %q to name item type %d, got %d", spec.Name, it, got)
+		}
+	}
+	if _, ok := ItemTypeNamed("unknown"); ok {
+		t.Fatal("expected an unknown name to be rejected")
+	}
+}
+
+func TestHazardWeightsComeFromLayout(t *testing.T) {
+	cfg := baseLayout()
+	cfg.HazardWeights = map[ItemType]float64{ItemTypePaddleShrink: 2}
+
+	tests := []struct {
+		name  string
+		table DropTable
+		item  ItemType
+		want  float64
+	}{
+		{"spec weights", DropTable{}, ItemTypePaddleShrink, 2},
+		{"hazard left out by the difficulty", DropTable{}, ItemTypeReverseControls, 0},
+		{"table without the hazard", DropTable{Weights: map[ItemType]float64{ItemTypeMultiball: 1}}, ItemTypePaddleShrink, 2},
+		{"table naming the hazard", DropTable{Weights: map[ItemType]float64{ItemTypePaddleShrink: 0}}, ItemTypePaddleShrink, 0},
+		{"beneficial items ignore hazards", DropTable{}, ItemTypeMultiball, 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.table.weight(tt.item, cfg); got != tt.want {
+				t.Fatalf("expected weight %v, got %v", tt.want, got)
+			}
+		})
+	}
+
+	cfg.Drops = DropTable{Chance: 1, Weights: map[ItemType]float64{}}
+	block := NewBlock(100, 100, 70, 30, BlockTypeNormal)
+	state := NewGameState(cfg, []Block{block})
+	dropItem(state, cfg, &block, &mockRandom{floats: []float64{0.5}})
+	if len(state.Items) != 1 || state.Items[0].Type != ItemTypePaddleShrink {
+		t.Fatalf("expected the hazard to drop, got %+v", state.Items)
+	}
+}
+
+func TestExtraLifeUpToMaxLives(t *testing.T) {
+	cfg := baseLayout()
+	cfg.Lives = 2
+	cfg.MaxLives = 3
+	state := NewGameState(cfg, nil)
+
+	gainLife(state, cfg)
+	gainLife(state, cfg)
+
+	if state.Lives != 3 {
+		t.Fatalf("expected lives capped at 3, got %d", state.Lives)
+	}
+	assertEvents(t, state.Events, []Event{LifeGained{Lives: 3}})
+}
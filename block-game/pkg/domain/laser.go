@@ -0,0 +1,73 @@
+package domain
+
+import "math"
+
+// Projectile is a laser shot rising from the paddle.
+type Projectile struct {
+	X, Y   float64 // top-left corner
+	Width  float64
+	Height float64
+	VY     float64 // px/s; negative while rising
+}
+
+// updateLaser fires a pair of projectiles from the paddle edges while the laser effect
+// is active and Fire is held, at most once per LayoutConfig.LaserCooldown.
+func updateLaser(state *GameState, input InputState, cfg LayoutConfig) {
+	if state.FireCooldown > 0 {
+		state.FireCooldown--
+	}
+	if !input.Fire || state.FireCooldown > 0 {
+		return
+	}
+	if _, ok := state.Effect(EffectLaser); !ok {
+		return
+	}
+
+	p := state.Paddle
+	y := p.Y - cfg.LaserHeight
+	for _, x := range [2]float64{p.X, p.X + p.Width - cfg.LaserWidth} {
+		state.Projectiles = append(state.Projectiles, Projectile{
+			X:      x,
+			Y:      y,
+			Width:  cfg.LaserWidth,
+			Height: cfg.LaserHeight,
+			VY:     -cfg.LaserSpeed,
+		})
+	}
+	state.FireCooldown = cfg.DurationTicks(cfg.LaserCooldown)
+	state.emit(LaserFired{X: p.X + p.Width/2})
+}
+
+// updateProjectiles moves every projectile one step. A projectile hits the first block
+// in its path once, like a ball would, and disappears; so does one leaving the screen.
+func updateProjectiles(state *GameState, cfg LayoutConfig, rnd RandomSource) {
+	var candidates []int
+	active := state.Projectiles[:0]
+	for _, p := range state.Projectiles {
+		dy := p.VY * cfg.StepSeconds()
+		// The tip is swept as a circle as wide as the projectile.
+		r := p.Width / 2
+		x, y := p.X+r, p.Y+r
+		candidates = state.BlockGrid(cfg).Query(p.X, p.Y+math.Min(dy, 0), p.Width, p.Height+math.Abs(dy), candidates[:0])
+		hit, first := -1, math.Inf(1)
+		for _, i := range candidates {
+			if !state.Blocks[i].Alive {
+				continue
+			}
+			if c, ok := sweepCircleBlock(x, y, 0, dy, r, state.Blocks[i]); ok && c.time < first {
+				hit, first = i, c.time
+			}
+		}
+		if hit >= 0 {
+			hitBlock(state, cfg, hit, rnd)
+			continue
+		}
+
+		p.Y += dy
+		if p.Y+p.Height < 0 {
+			continue
+		}
+		active = append(active, p)
+	}
+	state.Projectiles = active
+}
@@ -0,0 +1,102 @@
+package domain
+
+import "testing"
+
+func laserLayout() LayoutConfig {
+	cfg := baseLayout()
+	cfg.Drops.Chance = 0
+	cfg.LaserDuration = 1
+	cfg.LaserCooldown = 3.0 / 60
+	cfg.LaserSpeed = 600
+	cfg.LaserWidth = 4
+	cfg.LaserHeight = 12
+	return cfg
+}
+
+func TestFireNeedsLaserAndCooldown(t *testing.T) {
+	cfg := laserLayout()
+	state := NewGameState(cfg, []Block{NewBlock(0, 0, 70, 30, BlockTypeHard)})
+	fire := InputState{Fire: true}
+
+	Advance(state, fire, cfg, NewRandomSource(nil))
+	if len(state.Projectiles) != 0 {
+		t.Fatalf("expected no shots without the laser, got %d", len(state.Projectiles))
+	}
+
+	StartEffect(state, cfg, EffectLaser)
+	Advance(state, fire, cfg, NewRandomSource(nil))
+	if len(state.Projectiles) != 2 {
+		t.Fatalf("expected a pair of shots, got %d", len(state.Projectiles))
+	}
+	p := state.Paddle
+	left, right := state.Projectiles[0], state.Projectiles[1]
+	if left.X != p.X || right.X != p.X+p.Width-cfg.LaserWidth {
+		t.Fatalf("expected shots at the paddle edges, got x=%v and x=%v", left.X, right.X)
+	}
+	assertEvents(t, state.Events, []Event{LaserFired{X: p.X + p.Width/2}})
+
+	shots := []int{}
+	for i := 0; i < 6; i++ {
+		Advance(state, fire, cfg, NewRandomSource(nil))
+		shots = append(shots, len(state.Projectiles))
+	}
+	// one pair per cooldown of 3 steps
+	want := []int{2, 2, 4, 4, 4, 6}
+	for i := range want {
+		if shots[i] != want[i] {
+			t.Fatalf("expected projectile counts %v, got %v", want, shots)
+		}
+	}
+}
+
+func TestProjectileHitsFirstBlockInPath(t *testing.T) {
+	cfg := laserLayout()
+	near := NewBlock(90, 400, 20, 20, BlockTypeHard)
+	far := NewBlock(90, 300, 20, 20, BlockTypeNormal)
+	state := NewGameState(cfg, []Block{far, near})
+	// fast enough to reach the near block's whole height in one step
+	state.Projectiles = []Projectile{{X: 98, Y: 430, Width: 4, Height: 12, VY: -1800}}
+
+	updateProjectiles(state, cfg, NewRandomSource(nil))
+
+	if len(state.Projectiles) != 0 {
+		t.Fatalf("expected the projectile to stop at the block, got %+v", state.Projectiles)
+	}
+	if state.Blocks[1].HP != 2 || !state.Blocks[0].Alive || state.Blocks[0].HP != 1 {
+		t.Fatalf("expected only the near block damaged, got near HP %d, far %+v", state.Blocks[1].HP, state.Blocks[0])
+	}
+	assertEvents(t, state.Events, []Event{BlockHit{Block: 1, HP: 2}})
+}
+
+func TestProjectileLeavesTheScreen(t *testing.T) {
+	cfg := laserLayout()
+	state := NewGameState(cfg, []Block{NewBlock(500, 100, 70, 30, BlockTypeNormal)})
+	state.Projectiles = []Projectile{
+		{X: 100, Y: -5, Width: 4, Height: 12, VY: -cfg.LaserSpeed},
+		{X: 100, Y: 200, Width: 4, Height: 12, VY: -cfg.LaserSpeed},
+	}
+
+	updateProjectiles(state, cfg, NewRandomSource(nil))
+
+	if len(state.Projectiles) != 1 || state.Projectiles[0].Y != 190 {
+		t.Fatalf("expected the top projectile gone and the other 10px higher, got %+v", state.Projectiles)
+	}
+}
+
+func TestLoseLifeClearsProjectiles(t *testing.T) {
+	cfg := laserLayout()
+	cfg.Lives = 3
+	state := NewGameState(cfg, []Block{NewBlock(0, 0, 70, 30, BlockTypeNormal)})
+	StartEffect(state, cfg, EffectLaser)
+	state.Projectiles = []Projectile{{X: 100, Y: 200, Width: 4, Height: 12, VY: -cfg.LaserSpeed}}
+	state.Balls = state.Balls[:0]
+
+	loseLife(state, cfg)
+
+	if len(state.Projectiles) != 0 {
+		t.Fatalf("expected projectiles cleared, got %d", len(state.Projectiles))
+	}
+	if _, ok := state.Effect(EffectLaser); ok {
+		t.Fatal("expected the laser to end with the life")
+	}
+}
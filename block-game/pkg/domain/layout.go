@@ -3,73 +3,54 @@ package domain
 import (
 	"errors"
 	"math"
-	"math/rand"
-	"time"
 )
 
 type LayoutConfig struct {
 	ScreenW, ScreenH          float64
-	BlockW, BlockH            float64
+	BlockW, BlockH            float64 // size of generated blocks; loaded blocks carry their own
 	BlockRows, BlockCols      int
 	BlockSpacing              float64
 	PaddleWidth, PaddleHeight float64
 	PaddleY                   float64
-	PaddleSpeed               float64
+	PaddleSpeed               float64 // px/s
 	BallRadius                float64
-	BallSpeed                 float64
+	BallSpeed                 float64 // px/s
 	BlockCount                int
 	MinPaddleGap              float64
 	MaxAttempts               int
 	MaxBalls                  int
-	ItemDropChance            float64
+	Drops                     DropTable               // item drops of destroyed blocks
+	BlockDrops                map[BlockType]DropTable // per-block-type tables that replace Drops
 	MaxItems                  int
 	ItemWidth                 float64
 	ItemHeight                float64
-	ItemFallSpeed             float64
-	PaddleEnlargeChance       float64 // probability for paddle-enlarge item drop (e.g., 0.02 = 2%)
-	PaddleEnlargeDuration     int     // effect duration in ticks (e.g., 300 = 5 sec @ 60FPS)
-	PaddleEnlargeMultiplier   float64 // paddle width multiplier (e.g., 3.0)
+	ItemFallSpeed             float64               // px/s
+	PaddleEnlargeDuration     float64               // effect duration in seconds (e.g., 5.0)
+	PaddleEnlargeMultiplier   float64               // paddle width multiplier (e.g., 3.0)
+	LaserDuration             float64               // laser effect duration in seconds
+	LaserCooldown             float64               // seconds between two laser shots
+	LaserSpeed                float64               // projectile speed in px/s
+	LaserWidth, LaserHeight   float64               // projectile size
+	CatchDuration             float64               // catch effect duration in seconds
+	FireballDuration          float64               // fireball effect duration in seconds
+	PaddleShrinkDuration      float64               // paddle shrink effect duration in seconds
+	PaddleShrinkMultiplier    float64               // paddle width multiplier while shrunk (e.g., 0.6)
+	BallSpeedUpDuration       float64               // ball speed-up effect duration in seconds
+	BallSpeedUpMultiplier     float64               // ball speed multiplier while sped up (e.g., 1.3)
+	ReverseControlsDuration   float64               // reversed controls duration in seconds
+	BallSlowDuration          float64               // ball slow effect duration in seconds
+	BallSlowMultiplier        float64               // ball speed multiplier while slowed (e.g., 0.6)
+	HazardWeights             map[ItemType]float64  // drop weights of harmful items, set by the difficulty (see DropTable)
+	Lives                     int                   // balls the player may lose before game over (minimum 1)
+	MaxLives                  int                   // most lives extra-life items can bring; 0 means no limit
+	BarrierHeight             float64               // thickness of the barrier at the bottom of the screen
+	BlockTypeWeights          map[BlockType]float64 // relative weights for generated block types (nil = all normal)
+	BlockSizeScale            float64               // difficulty scale already applied to BlockW/BlockH; apply to level blocks via ScaleBlocks
+	TickRate                  int                   // fixed simulation steps per second (default 60)
 	Difficulty                Difficulty
 	Seed                      *int64
 }
 
-type RandomSource interface {
-	Float64() float64
-	Intn(n int) int
-	Seed(seed int64)
-}
-
-type defaultRandomSource struct {
-	r *rand.Rand
-}
-
-func (d *defaultRandomSource) Float64() float64 {
-	return d.r.Float64()
-}
-
-func (d *defaultRandomSource) Intn(n int) int {
-	return d.r.Intn(n)
-}
-
-func (d *defaultRandomSource) Seed(seed int64) {
-	d.r.Seed(seed)
-}
-
-func NewRandomSource(seed *int64) RandomSource {
-	seedVal := time.Now().UnixNano()
-	if seed != nil {
-		seedVal = *seed
-	}
-	return &defaultRandomSource{
-		r: rand.New(rand.NewSource(seedVal)),
-	}
-}
-
-type Block struct {
-	X, Y  float64
-	Alive bool
-}
-
 func rectsOverlap(ax, ay, aw, ah, bx, by, bw, bh float64) bool {
 	return ax < bx+bw && ax+aw > bx && ay < by+bh && ay+ah > by
 }
@@ -117,9 +98,10 @@ func GenerateBlocks(cfg LayoutConfig, rnd RandomSource) ([]Block, error) {
 		x := rnd.Float64() * maxX
 		y := rnd.Float64() * maxY
 
+		candidate := NewBlock(x, y, cfg.BlockW, cfg.BlockH, BlockTypeNormal)
 		overlap := false
 		for _, b := range blocks {
-			if rectsOverlap(x, y, cfg.BlockW, cfg.BlockH, b.X, b.Y, cfg.BlockW, cfg.BlockH) {
+			if candidate.overlaps(b) {
 				overlap = true
 				break
 			}
@@ -128,34 +110,33 @@ func GenerateBlocks(cfg LayoutConfig, rnd RandomSource) ([]Block, error) {
 			continue
 		}
 
-		blocks = append(blocks, Block{
-			X:     x,
-			Y:     y,
-			Alive: true,
-		})
+		blocks = append(blocks, NewBlock(x, y, cfg.BlockW, cfg.BlockH, pickBlockType(cfg, rnd)))
 	}
+	ensureDestructible(blocks)
 
 	return blocks, nil
 }
 
-func GenerateGridFallback(cfg LayoutConfig) []Block {
+func GenerateGridFallback(cfg LayoutConfig, rnd RandomSource) []Block {
 	total := cfg.BlockRows * cfg.BlockCols
 	if total <= 0 {
 		return []Block{}
 	}
+	if rnd == nil {
+		rnd = NewRandomSource(cfg.Seed)
+	}
 	blocks := make([]Block, total)
 	startX := (cfg.ScreenW - (float64(cfg.BlockCols)*(cfg.BlockW+cfg.BlockSpacing) - cfg.BlockSpacing)) / 2
 	startY := 50.0
 	for row := 0; row < cfg.BlockRows; row++ {
 		for col := 0; col < cfg.BlockCols; col++ {
 			idx := row*cfg.BlockCols + col
-			blocks[idx] = Block{
-				X:     startX + float64(col)*(cfg.BlockW+cfg.BlockSpacing),
-				Y:     startY + float64(row)*(cfg.BlockH+cfg.BlockSpacing),
-				Alive: true,
-			}
+			x := startX + float64(col)*(cfg.BlockW+cfg.BlockSpacing)
+			y := startY + float64(row)*(cfg.BlockH+cfg.BlockSpacing)
+			blocks[idx] = NewBlock(x, y, cfg.BlockW, cfg.BlockH, pickBlockType(cfg, rnd))
 		}
 	}
+	ensureDestructible(blocks)
 	return blocks
 }
 
@@ -23,29 +23,30 @@ func (m *mockRandom) Seed(seed int64) {}
 
 func baseLayout() LayoutConfig {
 	return LayoutConfig{
-		ScreenW:        800,
-		ScreenH:        600,
-		BlockW:         70,
-		BlockH:         30,
-		BlockRows:      5,
-		BlockCols:      10,
-		BlockSpacing:   5,
-		PaddleWidth:    100,
-		PaddleHeight:   20,
-		PaddleY:        550,
-		PaddleSpeed:    5,
-		BallRadius:     10,
-		BallSpeed:      5,
-		BlockCount:     10,
-		MinPaddleGap:   180,
-		MaxAttempts:    200,
-		MaxBalls:       8,
-		ItemDropChance: 0.1,
-		MaxItems:       3,
-		ItemWidth:      16,
-		ItemHeight:     12,
-		ItemFallSpeed:  3,
-		Seed:           nil,
+		ScreenW:       800,
+		ScreenH:       600,
+		BlockW:        70,
+		BlockH:        30,
+		BlockRows:     5,
+		BlockCols:     10,
+		BlockSpacing:  5,
+		PaddleWidth:   100,
+		PaddleHeight:  20,
+		PaddleY:       550,
+		PaddleSpeed:   300,
+		BallRadius:    10,
+		BallSpeed:     300,
+		BlockCount:    10,
+		MinPaddleGap:  180,
+		MaxAttempts:   200,
+		MaxBalls:      8,
+		Drops:         DropTable{Chance: 0.1},
+		MaxItems:      3,
+		ItemWidth:     16,
+		ItemHeight:    12,
+		ItemFallSpeed: 180,
+		TickRate:      60,
+		Seed:          nil,
 	}
 }
 
@@ -107,7 +108,7 @@ func TestGenerateBlocksInsufficientSpace(t *testing.T) {
 
 func TestGenerateGridFallback(t *testing.T) {
 	cfg := baseLayout()
-	blocks := GenerateGridFallback(cfg)
+	blocks := GenerateGridFallback(cfg, nil)
 	expected := cfg.BlockRows * cfg.BlockCols
 	if len(blocks) != expected {
 		t.Fatalf("expected %d blocks, got %d", expected, len(blocks))
@@ -118,6 +119,31 @@ func TestGenerateGridFallback(t *testing.T) {
 	}
 }
 
+func TestGeneratedStagesCanBeCleared(t *testing.T) {
+	cfg := baseLayout()
+	cfg.BlockCount = 10
+	cfg.BlockTypeWeights = map[BlockType]float64{BlockTypeIndestructible: 100, BlockTypeNormal: 0.001}
+	seed := int64(3)
+
+	blocks, err := GenerateBlocks(cfg, NewRandomSource(&seed))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	grid := GenerateGridFallback(cfg, NewRandomSource(&seed))
+	for name, bs := range map[string][]Block{"random": blocks, "grid": grid} {
+		state := &GameState{Blocks: bs}
+		destructible := 0
+		for _, b := range bs {
+			if b.Destructible() {
+				destructible++
+			}
+		}
+		if destructible != 1 || state.BlocksCleared() {
+			t.Fatalf("%s: expected exactly one destructible block, got %d", name, destructible)
+		}
+	}
+}
+
 func TestGenerateBlocksHardLayoutIntegration(t *testing.T) {
 	base := baseLayout()
 	base.BlockCount = 50
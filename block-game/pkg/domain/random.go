@@ -0,0 +1,134 @@
+package domain
+
+import (
+	"math/bits"
+	"time"
+)
+
+type RandomSource interface {
+	Float64() float64
+	Intn(n int) int
+	Seed(seed int64)
+}
+
+// RandomState is the complete state of a PCG generator; restoring it resumes the exact sequence.
+type RandomState struct {
+	State uint64
+	Inc   uint64 // stream selector, always odd
+}
+
+// StatefulRandom is a RandomSource whose position can be saved and restored.
+type StatefulRandom interface {
+	RandomSource
+	State() RandomState
+}
+
+// RandomStream names an independent sequence split off a seeded source, so that draws
+// added to one feature do not shift the numbers another feature sees.
+type RandomStream uint64
+
+const (
+	StreamBlocks RandomStream = iota + 1 // block placement and types
+	StreamItems                          // item drops during play
+)
+
+// Splitter is a RandomSource that can split off independent streams.
+type Splitter interface {
+	RandomSource
+	Split(stream RandomStream) RandomSource
+}
+
+// SplitStream returns an independent stream of rnd, or rnd itself when it cannot be split
+// (e.g. a scripted source in tests).
+func SplitStream(rnd RandomSource, stream RandomStream) RandomSource {
+	if s, ok := rnd.(Splitter); ok {
+		return s.Split(stream)
+	}
+	return rnd
+}
+
+const pcgMultiplier = 6364136223846793005
+
+// PCG is the PCG-XSH-RR 64/32 generator (pcg32). Its output depends only on its two
+// words of state, so sequences are identical on every platform and Go version.
+type PCG struct {
+	state uint64
+	inc   uint64
+}
+
+// NewPCG seeds a generator on the given stream, as pcg32_srandom does.
+func NewPCG(seed, stream uint64) *PCG {
+	p := &PCG{}
+	p.reset(seed, stream<<1|1)
+	return p
+}
+
+func (p *PCG) reset(seed, inc uint64) {
+	p.state = 0
+	p.inc = inc
+	p.Uint32()
+	p.state += seed
+	p.Uint32()
+}
+
+// Uint32 returns the next 32 bits of the sequence.
+func (p *PCG) Uint32() uint32 {
+	old := p.state
+	p.state = old*pcgMultiplier + p.inc
+	xorshifted := uint32(((old >> 18) ^ old) >> 27)
+	return bits.RotateLeft32(xorshifted, -int(old>>59))
+}
+
+// Uint64 joins two outputs, high word first.
+func (p *PCG) Uint64() uint64 {
+	hi := uint64(p.Uint32())
+	return hi<<32 | uint64(p.Uint32())
+}
+
+// Float64 returns a value in [0, 1) with 53 random bits.
+func (p *PCG) Float64() float64 {
+	return float64(p.Uint64()>>11) / (1 << 53)
+}
+
+// Intn returns an unbiased value in [0, n). It panics if n <= 0.
+func (p *PCG) Intn(n int) int {
+	if n <= 0 {
+		panic("domain: invalid argument to Intn")
+	}
+	bound := uint64(n)
+	threshold := -bound % bound // values below this would favour small results
+	for {
+		if r := p.Uint64(); r >= threshold {
+			return int(r % bound)
+		}
+	}
+}
+
+// Seed restarts the generator's current stream from seed.
+func (p *PCG) Seed(seed int64) {
+	p.reset(uint64(seed), p.inc)
+}
+
+func (p *PCG) State() RandomState {
+	return RandomState{State: p.state, Inc: p.inc}
+}
+
+// Split returns a generator on stream seeded from the next output of p. Splitting
+// advances p, so repeated splits of one stream are independent of each other.
+func (p *PCG) Split(stream RandomStream) RandomSource {
+	return NewPCG(p.Uint64(), uint64(stream))
+}
+
+// NewRandomSource returns a PCG on stream 0 seeded with seed, or with the clock if seed is nil.
+func NewRandomSource(seed *int64) RandomSource {
+	seedVal := time.Now().UnixNano()
+	if seed != nil {
+		seedVal = *seed
+	}
+	return NewPCG(uint64(seedVal), 0)
+}
+
+// RestoreRandomSource returns a source positioned exactly where state was taken.
+func RestoreRandomSource(state RandomState) RandomSource {
+	return &PCG{state: state.State, inc: state.Inc | 1}
+}
@@ -0,0 +1,126 @@
+package domain
+
+import "testing"
+
+// The golden values below pin the exact sequences; they must not change between Go
+// versions or platforms, or saved games and replays would no longer reproduce.
+
+func TestPCGMatchesReferenceSequence(t *testing.T) {
+	// pcg32_srandom(42, 54) from the PCG reference implementation.
+	want := []uint32{0xa15c02b7, 0x7b47f409, 0xba1d3330, 0x83d2f293, 0xbfa4784b, 0xcbed606e}
+	p := NewPCG(42, 54)
+	for i, w := range want {
+		if got := p.Uint32(); got != w {
+			t.Fatalf("output %d: got %#x, want %#x", i, got, w)
+		}
+	}
+}
+
+func TestRandomSourceGoldenSequence(t *testing.T) {
+	seed := int64(2024)
+	rnd := NewRandomSource(&seed)
+
+	floats := []float64{0.6426945049899766, 0.18710580788320352, 0.7126328800719961}
+	for i, want := range floats {
+		if got := rnd.Float64(); got != want {
+			t.Fatalf("Float64 %d: got %v, want %v", i, got, want)
+		}
+	}
+	ints := []int{5, 3, 9, 8, 4}
+	for i, want := range ints {
+		if got := rnd.Intn(10); got != want {
+			t.Fatalf("Intn %d: got %d, want %d", i, got, want)
+		}
+	}
+
+	blocks := SplitStream(rnd, StreamBlocks)
+	items := SplitStream(rnd, StreamItems)
+	if got := blocks.Float64(); got != 0.14397514538652412 {
+		t.Fatalf("blocks stream: got %v", got)
+	}
+	if got := items.Float64(); got != 0.329434819057853 {
+		t.Fatalf("items stream: got %v", got)
+	}
+	if got := rnd.(StatefulRandom).State(); got != (RandomState{State: 10164992318465729938, Inc: 1}) {
+		t.Fatalf("root state: got %+v", got)
+	}
+}
+
+func TestSplitStreamsAreIndependent(t *testing.T) {
+	draw := func(extraItemDraws int) []float64 {
+		seed := int64(9)
+		rnd := NewRandomSource(&seed)
+		blocks := SplitStream(rnd, StreamBlocks)
+		items := SplitStream(rnd, StreamItems)
+		for i := 0; i < extraItemDraws; i++ {
+			items.Float64()
+		}
+		return []float64{blocks.Float64(), blocks.Float64(), blocks.Float64()}
+	}
+	a, b := draw(0), draw(17)
+	for i := range a {
+		if a[i] != b[i] {
+			t.Fatalf("draws from the items stream shifted the blocks stream at %d", i)
+		}
+	}
+
+	p := NewPCG(1, 0)
+	if x, y := p.Split(StreamItems).Float64(), p.Split(StreamItems).Float64(); x == y {
+		t.Fatalf("repeated splits should give different sequences")
+	}
+}
+
+func TestSplitStreamFallsBackToSource(t *testing.T) {
+	m := &mockRandom{floats: []float64{0.25}}
+	if SplitStream(m, StreamItems) != RandomSource(m) {
+		t.Fatalf("a source that cannot split should be used as is")
+	}
+}
+
+func TestRestoreRandomSourceContinuesSequence(t *testing.T) {
+	seed := int64(7)
+	rnd := NewRandomSource(&seed)
+	for i := 0; i < 100; i++ {
+		rnd.Float64()
+		rnd.Intn(1000003)
+	}
+	restored := RestoreRandomSource(rnd.(StatefulRandom).State())
+	for i := 0; i < 50; i++ {
+		if a, b := rnd.Float64(), restored.Float64(); a != b {
+			t.Fatalf("draw %d: got %v, want %v", i, b, a)
+		}
+		if a, b := rnd.Intn(97), restored.Intn(97); a != b {
+			t.Fatalf("draw %d: got %d, want %d", i, b, a)
+		}
+	}
+}
+
+func TestPCGSeedRestartsStream(t *testing.T) {
+	p := NewPCG(5, 3)
+	first := p.Uint64()
+	p.Uint64()
+	p.Seed(5)
+	if got := p.Uint64(); got != first {
+		t.Fatalf("reseeding should restart the sequence: got %#x, want %#x", got, first)
+	}
+	if p.State().Inc != 3<<1|1 {
+		t.Fatalf("reseeding should keep the stream, got inc %d", p.State().Inc)
+	}
+}
+
+func TestPCGIntnBounds(t *testing.T) {
+	p := NewPCG(3, 0)
+	for _, n := range []int{1, 2, 7, 1 << 40} {
+		for i := 0; i < 200; i++ {
+			if v := p.Intn(n); v < 0 || v >= n {
+				t.Fatalf("Intn(%d) returned %d", n, v)
+			}
+		}
+	}
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("Intn(0) should panic")
+		}
+	}()
+	p.Intn(0)
+}
@@ -0,0 +1,93 @@
+package domain
+
+import "math"
+
+// launchSpread is the maximum launch deviation from vertical, reached at the screen edges.
+const launchSpread = math.Pi / 4
+
+// newServeBall returns a ball resting on the centre of the paddle, waiting for launch.
+func newServeBall(paddle Paddle, cfg LayoutConfig) Ball {
+	return Ball{
+		X:        paddle.X + paddle.Width/2,
+		Y:        paddle.Y - cfg.BallRadius,
+		Radius:   cfg.BallRadius,
+		Speed:    cfg.BallSpeed,
+		Attached: true,
+	}
+}
+
+// updateAttachedBalls keeps attached balls on the paddle and launches them on input.
+// Served balls leave at launchAngle, caught balls at the angle they were caught with;
+// both at their own speed. Caught balls are released without input once the catch
+// effect has expired or been cancelled, so that none stays stuck on the paddle.
+func updateAttachedBalls(state *GameState, input InputState, cfg LayoutConfig) {
+	_, catching := state.Effect(EffectCatch)
+	for i := range state.Balls {
+		ball := &state.Balls[i]
+		if !ball.Attached {
+			continue
+		}
+		// keep the ball over the paddle even if the paddle shrank meanwhile
+		half := state.Paddle.Width / 2
+		ball.X = state.Paddle.X + half + math.Min(math.Max(ball.AttachOffset, -half), half)
+		ball.Y = state.Paddle.Y - ball.Radius
+		// caught balls also leave by themselves once the catch effect is over
+		if input.Launch || (ball.Caught && !catching) {
+			angle := launchAngle(state.Paddle, cfg)
+			if ball.Caught {
+				angle = ball.ReleaseAngle
+			}
+			launchBall(ball, angle, ball.Speed, state.Modifier(ModBallSpeed))
+		}
+	}
+}
+
+// catchBall sticks a ball to the paddle where it touched it, to be released at angle.
+func catchBall(state *GameState, ball *Ball, angle float64) {
+	ball.Attached = true
+	ball.Caught = true
+	ball.ReleaseAngle = angle
+	ball.AttachOffset = ball.X - (state.Paddle.X + state.Paddle.Width/2)
+	ball.Y = state.Paddle.Y - ball.Radius
+	ball.VX, ball.VY = 0, 0
+	state.emit(BallCaught{X: ball.X})
+}
+
+// launchAngle maps the paddle position to a launch direction: straight up at the
+// centre of the screen, tilting toward the side the paddle has moved to.
+func launchAngle(paddle Paddle, cfg LayoutConfig) float64 {
+	pos := (paddle.X + paddle.Width/2) / cfg.ScreenW
+	pos = math.Min(math.Max(pos, 0), 1)
+	return math.Pi/2 - (pos*2-1)*launchSpread
+}
+
+// launchBall sends the ball off at angle with base speed speed, scaled by the active
+// ball speed effects.
+func launchBall(ball *Ball, angle, speed, scale float64) {
+	ball.Attached = false
+	ball.AttachOffset = 0
+	ball.Caught = false
+	ball.ReleaseAngle = 0
+	ball.Speed = speed
+	ball.aim(angle, speed*scale)
+}
+
+// loseLife consumes a life after the last ball fell. With lives left, the field is
+// cleared of items, projectiles and effects and a new ball is served on the paddle.
+func loseLife(state *GameState, cfg LayoutConfig) {
+	state.Lives--
+	state.Stats.LivesLost++
+	if state.Lives <= 0 {
+		state.Lives = 0
+		state.emit(LifeLost{Lives: 0})
+		state.GameOver = true
+		state.emit(GameOver{})
+		return
+	}
+	state.emit(LifeLost{Lives: state.Lives})
+
+	state.Items = state.Items[:0]
+	state.Projectiles = state.Projectiles[:0]
+	clearEffects(state)
+	state.Balls = append(state.Balls[:0], newServeBall(state.Paddle, cfg))
+}
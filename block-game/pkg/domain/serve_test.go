@@ -0,0 +1,214 @@
+package domain
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNewGameStateServesBallOnPaddle(t *testing.T) {
+	cfg := baseLayout()
+	cfg.Lives = 3
+	state := NewGameState(cfg, []Block{})
+
+	if state.Lives != 3 {
+		t.Fatalf("expected 3 lives, got %d", state.Lives)
+	}
+	b := state.Balls[0]
+	if !b.Attached {
+		t.Fatalf("expected ball to wait on the paddle")
+	}
+	if b.X != state.Paddle.X+state.Paddle.Width/2 || b.Y != state.Paddle.Y-b.Radius {
+		t.Fatalf("expected ball on paddle centre, got (%f,%f)", b.X, b.Y)
+	}
+}
+
+func TestAttachedBallFollowsPaddleUntilLaunch(t *testing.T) {
+	cfg := baseLayout()
+	state := NewGameState(cfg, []Block{})
+
+	for i := 0; i < 10; i++ {
+		Advance(state, InputState{MoveLeft: true}, cfg, NewRandomSource(nil))
+	}
+	b := state.Balls[0]
+	if !b.Attached || b.X != state.Paddle.X+state.Paddle.Width/2 {
+		t.Fatalf("expected ball to follow the paddle, got %+v (paddle X=%f)", b, state.Paddle.X)
+	}
+
+	Advance(state, InputState{Launch: true}, cfg, NewRandomSource(nil))
+	b = state.Balls[0]
+	if b.Attached {
+		t.Fatalf("expected ball to be launched")
+	}
+	if b.VY >= 0 {
+		t.Fatalf("expected ball to launch upward, got VY=%f", b.VY)
+	}
+	if b.VX >= 0 {
+		t.Fatalf("expected launch to tilt left with the paddle left of centre, got VX=%f", b.VX)
+	}
+}
+
+func TestLaunchAngleFollowsPaddlePosition(t *testing.T) {
+	cfg := baseLayout()
+	tests := []struct {
+		name    string
+		paddleX float64
+		wantVX  func(float64) bool
+	}{
+		{"left edge tilts left", 0, func(vx float64) bool { return vx < 0 }},
+		{"centre goes straight up", (cfg.ScreenW - cfg.PaddleWidth) / 2, func(vx float64) bool { return vx > -1e-9 && vx < 1e-9 }},
+		{"right edge tilts right", cfg.ScreenW - cfg.PaddleWidth, func(vx float64) bool { return vx > 0 }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paddle := Paddle{X: tt.paddleX, Width: cfg.PaddleWidth}
+			var b Ball
+			launchBall(&b, launchAngle(paddle, cfg), cfg.BallSpeed, 1)
+			if !tt.wantVX(b.VX) || b.VY >= 0 {
+				t.Fatalf("unexpected launch velocity (%f,%f)", b.VX, b.VY)
+			}
+		})
+	}
+}
+
+func TestLosingLastBallConsumesLifeAndResetsField(t *testing.T) {
+	cfg := baseLayout()
+	cfg.Lives = 2
+	cfg.PaddleEnlargeDuration = 5
+	cfg.PaddleEnlargeMultiplier = 3
+	state := newLaunchedState(cfg, []Block{{X: 100, Y: 100, W: 70, H: 30, Alive: true}})
+	StartEffect(state, cfg, EffectPaddleEnlarge)
+	state.Items = append(state.Items, Item{Y: 10, Active: true})
+	state.Balls[0].Y = cfg.ScreenH + state.Balls[0].Radius + 1
+
+	Advance(state, InputState{}, cfg, NewRandomSource(nil))
+
+	if state.GameOver {
+		t.Fatalf("expected game to continue with a life left")
+	}
+	if state.Lives != 1 {
+		t.Fatalf("expected 1 life left, got %d", state.Lives)
+	}
+	if len(state.Items) != 0 {
+		t.Fatalf("expected items to be cleared, got %d", len(state.Items))
+	}
+	if len(state.Effects) != 0 || state.Paddle.Width != cfg.PaddleWidth {
+		t.Fatalf("expected paddle effect reset, got width %f", state.Paddle.Width)
+	}
+	if len(state.Balls) != 1 || !state.Balls[0].Attached {
+		t.Fatalf("expected a new ball served on the paddle")
+	}
+
+	launchBall(&state.Balls[0], launchAngle(state.Paddle, cfg), cfg.BallSpeed, 1)
+	state.Balls[0].Y = cfg.ScreenH + state.Balls[0].Radius + 1
+	Advance(state, InputState{}, cfg, NewRandomSource(nil))
+
+	if !state.GameOver || state.Lives != 0 {
+		t.Fatalf("expected game over after the last life, got lives=%d gameOver=%v", state.Lives, state.GameOver)
+	}
+}
+
+func TestCatchEffectHoldsBallsUntilLaunch(t *testing.T) {
+	cfg := baseLayout()
+	cfg.CatchDuration = 1
+	state := NewGameState(cfg, []Block{NewBlock(0, 0, 70, 30, BlockTypeNormal)})
+	StartEffect(state, cfg, EffectCatch)
+	p := state.Paddle
+	state.Balls = []Ball{
+		{X: p.X + 10, Y: p.Y - cfg.BallRadius - 1, VY: cfg.BallSpeed, Radius: cfg.BallRadius, Speed: cfg.BallSpeed},
+		{X: p.X + p.Width - 10, Y: p.Y - cfg.BallRadius - 1, VY: 2 * cfg.BallSpeed, Radius: cfg.BallRadius, Speed: 2 * cfg.BallSpeed},
+	}
+	// each ball is released at its own speed, not the layout's
+	speeds := []float64{cfg.BallSpeed, 2 * cfg.BallSpeed}
+
+	Advance(state, InputState{}, cfg, NewRandomSource(nil))
+	assertEvents(t, state.Events, []Event{BallCaught{X: p.X + 10}, BallCaught{X: p.X + p.Width - 10}})
+	angles := make([]float64, len(state.Balls))
+	for i, b := range state.Balls {
+		if !b.Attached || !b.Caught || b.VX != 0 || b.VY != 0 {
+			t.Fatalf("expected ball %d to stick, got %+v", i, b)
+		}
+		if want := paddleBounceAngle(p, b.X); b.ReleaseAngle != want {
+			t.Fatalf("expected ball %d to keep the bounce angle %v, got %v", i, want, b.ReleaseAngle)
+		}
+		angles[i] = b.ReleaseAngle
+	}
+
+	for i := 0; i < 5; i++ {
+		Advance(state, InputState{MoveRight: true}, cfg, NewRandomSource(nil))
+	}
+	if got := state.Balls[0].X - state.Paddle.X; got != 10 {
+		t.Fatalf("expected the ball to ride at the same spot, got offset %v", got)
+	}
+
+	Advance(state, InputState{Launch: true}, cfg, NewRandomSource(nil))
+	for i, b := range state.Balls {
+		if b.Attached || b.Caught {
+			t.Fatalf("expected ball %d to be released, got %+v", i, b)
+		}
+		vx, vy := speeds[i]*math.Cos(angles[i]), -speeds[i]*math.Sin(angles[i])
+		if math.Abs(b.VX-vx) > 1e-9 || math.Abs(b.VY-vy) > 1e-9 {
+			t.Fatalf("expected ball %d released at its own angle and speed (%v,%v), got (%v,%v)", i, vx, vy, b.VX, b.VY)
+		}
+	}
+}
+
+func TestBallsBounceOnceCatchExpires(t *testing.T) {
+	cfg := baseLayout()
+	cfg.CatchDuration = 2.0 / 60
+	state := NewGameState(cfg, []Block{NewBlock(0, 0, 70, 30, BlockTypeNormal)})
+	StartEffect(state, cfg, EffectCatch)
+	Advance(state, InputState{Launch: true}, cfg, NewRandomSource(nil))
+	Advance(state, InputState{}, cfg, NewRandomSource(nil))
+	if _, ok := state.Effect(EffectCatch); ok {
+		t.Fatal("expected the catch effect to expire")
+	}
+
+	p := state.Paddle
+	state.Balls = []Ball{{X: p.X + p.Width/2, Y: p.Y - cfg.BallRadius - 1, VY: cfg.BallSpeed, Radius: cfg.BallRadius, Speed: cfg.BallSpeed}}
+	Advance(state, InputState{}, cfg, NewRandomSource(nil))
+	if b := state.Balls[0]; b.Attached || b.VY >= 0 {
+		t.Fatalf("expected the ball to bounce, got %+v", b)
+	}
+}
+
+func TestCaughtBallsReleaseWhenCatchEnds(t *testing.T) {
+	cfg := baseLayout()
+	cfg.CatchDuration = 3.0 / 60
+	p := NewGameState(cfg, nil).Paddle
+	caught := Ball{X: p.X + 10, Y: p.Y - cfg.BallRadius - 1, VY: cfg.BallSpeed, Radius: cfg.BallRadius, Speed: cfg.BallSpeed}
+
+	for _, end := range []struct {
+		name string
+		run  func(state *GameState)
+	}{
+		{"expired", func(state *GameState) {
+			for i := 0; i < 2; i++ {
+				updateEffects(state)
+			}
+		}},
+		{"cancelled", clearEffects},
+	} {
+		t.Run(end.name, func(t *testing.T) {
+			state := NewGameState(cfg, []Block{NewBlock(0, 0, 70, 30, BlockTypeNormal)})
+			StartEffect(state, cfg, EffectCatch)
+			state.Balls = []Ball{caught}
+			Advance(state, InputState{}, cfg, NewRandomSource(nil))
+			if !state.Balls[0].Caught {
+				t.Fatalf("expected the ball to be caught, got %+v", state.Balls[0])
+			}
+			angle := state.Balls[0].ReleaseAngle
+
+			end.run(state)
+			Advance(state, InputState{}, cfg, NewRandomSource(nil))
+
+			b := state.Balls[0]
+			if _, ok := state.Effect(EffectCatch); ok || b.Attached || b.Caught {
+				t.Fatalf("expected the ball released with the effect gone, got %+v", b)
+			}
+			vx, vy := cfg.BallSpeed*math.Cos(angle), -cfg.BallSpeed*math.Sin(angle)
+			if math.Abs(b.VX-vx) > 1e-9 || math.Abs(b.VY-vy) > 1e-9 {
+				t.Fatalf("expected release at the catch angle (%v,%v), got (%v,%v)", vx, vy, b.VX, b.VY)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package domain
+
+import "math"
+
+// StageRules describe how a campaign ramps up between stages and rewards a clear.
+// Stages are numbered from 0.
+type StageRules struct {
+	BallSpeedStep      float64 // ball speed increase per stage, as a fraction of the first stage's speed
+	PaddleWidthStep    float64 // paddle width decrease per stage, as a fraction
+	MaxRampStages      int     // the ramp stops growing after this many stages
+	ClearBonus         int     // points per stage number (1-based) for clearing it
+	LifeBonus          int     // points per life left at the clear
+	PerfectBonus       int     // points for clearing a stage without losing a life
+	PerfectPaddleBonus float64 // permanent paddle width upgrade for a perfect clear
+	MaxPaddleBonus     float64 // cap on the accumulated paddle width upgrade
+}
+
+// Upgrades persist across the stages of a campaign.
+type Upgrades struct {
+	PaddleWidthBonus float64 // fraction added to the paddle width
+}
+
+// StageTally is the score summary shown when a stage is cleared.
+type StageTally struct {
+	Stage        int // 0-based
+	BlockScore   int // points scored from blocks during the stage
+	ClearBonus   int
+	LifeBonus    int
+	PerfectBonus int
+	Perfect      bool
+	Upgraded     bool // the perfect clear raised Upgrades.PaddleWidthBonus
+}
+
+// Bonus is the total bonus awarded on top of the block score.
+func (t StageTally) Bonus() int {
+	return t.ClearBonus + t.LifeBonus + t.PerfectBonus
+}
+
+// Ramp returns cfg adjusted for the given stage and the player's upgrades.
+func (r StageRules) Ramp(cfg LayoutConfig, stage int, upgrades Upgrades) LayoutConfig {
+	step := float64(stage)
+	if r.MaxRampStages > 0 {
+		step = math.Min(step, float64(r.MaxRampStages))
+	}
+	cfg.BallSpeed *= 1 + r.BallSpeedStep*step
+	cfg.PaddleWidth *= math.Max(1-r.PaddleWidthStep*step, 0.5)
+	cfg.PaddleWidth *= 1 + upgrades.PaddleWidthBonus
+	cfg.PaddleWidth = math.Min(cfg.PaddleWidth, cfg.ScreenW)
+	return cfg
+}
+
+// Tally scores a cleared stage and applies the perfect-clear upgrade to upgrades.
+// blockScore is the score earned during the stage, livesLost how many lives it cost.
+func (r StageRules) Tally(stage, blockScore, livesLeft, livesLost int, upgrades *Upgrades) StageTally {
+	t := StageTally{
+		Stage:      stage,
+		BlockScore: blockScore,
+		ClearBonus: r.ClearBonus * (stage + 1),
+		LifeBonus:  r.LifeBonus * livesLeft,
+		Perfect:    livesLost == 0,
+	}
+	if t.Perfect {
+		t.PerfectBonus = r.PerfectBonus
+		if upgrades != nil && upgrades.PaddleWidthBonus < r.MaxPaddleBonus {
+			upgrades.PaddleWidthBonus = math.Min(upgrades.PaddleWidthBonus+r.PerfectPaddleBonus, r.MaxPaddleBonus)
+			t.Upgraded = true
+		}
+	}
+	return t
+}
@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"math"
+	"testing"
+)
+
+func testStageRules() StageRules {
+	return StageRules{
+		BallSpeedStep:      0.1,
+		PaddleWidthStep:    0.05,
+		MaxRampStages:      3,
+		ClearBonus:         10,
+		LifeBonus:          5,
+		PerfectBonus:       20,
+		PerfectPaddleBonus: 0.1,
+		MaxPaddleBonus:     0.2,
+	}
+}
+
+func TestStageRulesRamp(t *testing.T) {
+	cfg := baseLayout()
+	rules := testStageRules()
+
+	tests := []struct {
+		name      string
+		stage     int
+		upgrades  Upgrades
+		wantSpeed float64
+		wantWidth float64
+	}{
+		{"first stage unchanged", 0, Upgrades{}, 300, 100},
+		{"second stage", 1, Upgrades{}, 330, 95},
+		{"ramp capped", 10, Upgrades{}, 390, 85},
+		{"upgrade widens paddle", 1, Upgrades{PaddleWidthBonus: 0.2}, 330, 114},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := rules.Ramp(cfg, tt.stage, tt.upgrades)
+			if math.Abs(got.BallSpeed-tt.wantSpeed) > 1e-9 || math.Abs(got.PaddleWidth-tt.wantWidth) > 1e-9 {
+				t.Fatalf("got speed %v width %v, want %v %v", got.BallSpeed, got.PaddleWidth, tt.wantSpeed, tt.wantWidth)
+			}
+		})
+	}
+}
+
+func TestStageRulesTally(t *testing.T) {
+	rules := testStageRules()
+	var upgrades Upgrades
+
+	perfect := rules.Tally(1, 40, 3, 0, &upgrades)
+	if perfect.ClearBonus != 20 || perfect.LifeBonus != 15 || perfect.PerfectBonus != 20 || perfect.Bonus() != 55 {
+		t.Fatalf("unexpected perfect tally: %+v", perfect)
+	}
+	if !perfect.Upgraded || math.Abs(upgrades.PaddleWidthBonus-0.1) > 1e-9 {
+		t.Fatalf("perfect clear should upgrade the paddle: %+v %+v", perfect, upgrades)
+	}
+
+	rules.Tally(2, 10, 3, 0, &upgrades)
+	capped := rules.Tally(3, 10, 3, 0, &upgrades)
+	if capped.Upgraded || math.Abs(upgrades.PaddleWidthBonus-0.2) > 1e-9 {
+		t.Fatalf("upgrade should stop at the cap: %+v %+v", capped, upgrades)
+	}
+
+	lost := rules.Tally(0, 10, 1, 2, &upgrades)
+	if lost.Perfect || lost.PerfectBonus != 0 || lost.Upgraded {
+		t.Fatalf("losing a life forfeits the perfect bonus: %+v", lost)
+	}
+}
@@ -0,0 +1,39 @@
+package level
+
+import (
+	"embed"
+	"errors"
+	"io/fs"
+	"path"
+
+	"block-game/pkg/domain"
+)
+
+//go:embed levels/*.json
+var bundled embed.FS
+
+// Campaign loads the bundled levels in file name order, validated against base.
+func Campaign(base domain.LayoutConfig) ([]*Level, error) {
+	names, err := fs.Glob(bundled, "levels/*.json")
+	if err != nil {
+		return nil, err
+	}
+	levels := make([]*Level, 0, len(names))
+	var errs []error
+	for _, name := range names {
+		data, err := bundled.ReadFile(name)
+		if err != nil {
+			return nil, err
+		}
+		lvl, err := Load(path.Base(name), data, base)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		levels = append(levels, lvl)
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return levels, nil
+}
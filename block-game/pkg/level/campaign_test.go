@@ -0,0 +1,27 @@
+package level
+
+import (
+	"testing"
+
+	"block-game/pkg/domain"
+)
+
+func TestCampaignLoadsBundledLevels(t *testing.T) {
+	levels, err := Campaign(testLayout())
+	if err != nil {
+		t.Fatalf("bundled levels should be valid: %v", err)
+	}
+	if len(levels) == 0 {
+		t.Fatalf("expected bundled levels")
+	}
+	for _, lvl := range levels {
+		if lvl.Name == "" {
+			t.Errorf("bundled level without a name")
+		}
+		blocks := lvl.BuildBlocks(lvl.ApplyTo(testLayout()))
+		state := domain.GameState{Blocks: blocks}
+		if len(blocks) == 0 || state.BlocksCleared() {
+			t.Errorf("%s: expected blocks to clear", lvl.Name)
+		}
+	}
+}
@@ -0,0 +1,239 @@
+// Package level defines the JSON level format, its loader and the bundled campaign.
+package level
+
+import (
+	"fmt"
+	"math"
+
+	"block-game/pkg/domain"
+)
+
+// Level describes a stage: optional screen/ball/paddle/drop overrides and its blocks.
+// Blocks come from an optional character grid followed by an explicit block list.
+type Level struct {
+	Name   string      `json:"name"`
+	Screen *ScreenSpec `json:"screen,omitempty"`
+	Ball   *BallSpec   `json:"ball,omitempty"`
+	Paddle *PaddleSpec `json:"paddle,omitempty"`
+	Drops  *DropSpec   `json:"drops,omitempty"`
+	Grid   *GridSpec   `json:"grid,omitempty"`
+	Blocks []BlockSpec `json:"blocks,omitempty"`
+
+	src *source // positions of every JSON value, for error reporting
+}
+
+type ScreenSpec struct {
+	Width  *float64 `json:"width,omitempty"`
+	Height *float64 `json:"height,omitempty"`
+}
+
+type BallSpec struct {
+	Speed  *float64 `json:"speed,omitempty"` // px/s
+	Radius *float64 `json:"radius,omitempty"`
+}
+
+type PaddleSpec struct {
+	Width *float64 `json:"width,omitempty"`
+	Speed *float64 `json:"speed,omitempty"` // px/s
+}
+
+// DropSpec overrides the item drops for this level. Blocks gives block types
+// (see blockTypes) their own table, based on the level's.
+type DropSpec struct {
+	DropTableSpec
+	Blocks map[string]DropTableSpec `json:"blocks,omitempty"`
+}
+
+// DropTableSpec overrides a drop table.
+type DropTableSpec struct {
+	Chance  *float64           `json:"chance,omitempty"`  // 0..1
+	Weights map[string]float64 `json:"weights,omitempty"` // by item name (see itemTypes); replaces every weight
+}
+
+// apply returns table with the spec's overrides.
+func (s DropTableSpec) apply(table domain.DropTable) domain.DropTable {
+	setFloat(&table.Chance, s.Chance)
+	if s.Weights != nil {
+		table.Weights = make(map[domain.ItemType]float64, len(s.Weights))
+		for name, w := range s.Weights {
+			table.Weights[itemTypes[name]] = w
+		}
+	}
+	return table
+}
+
+// GridSpec lays out blocks from rows of characters, one character per cell.
+// See gridTypes for the legend; '.' or ' ' leaves the cell empty.
+type GridSpec struct {
+	X       float64  `json:"x"`
+	Y       float64  `json:"y"`
+	BlockW  float64  `json:"blockW"`
+	BlockH  float64  `json:"blockH"`
+	Spacing float64  `json:"spacing"`
+	Rows    []string `json:"rows"`
+}
+
+// BlockSpec is a single explicitly placed block.
+type BlockSpec struct {
+	X     float64 `json:"x"`
+	Y     float64 `json:"y"`
+	W     float64 `json:"w"`
+	H     float64 `json:"h"`
+	Type  string  `json:"type,omitempty"`  // see blockTypes; default "normal"
+	HP    *int    `json:"hp,omitempty"`    // default from the block type
+	Shape string  `json:"shape,omitempty"` // "rect" (default), "circle" or "rotated"
+	Angle float64 `json:"angle,omitempty"` // degrees, for "rotated"
+}
+
+var blockTypes = map[string]domain.BlockType{
+	"normal":         domain.BlockTypeNormal,
+	"hard":           domain.BlockTypeHard,
+	"indestructible": domain.BlockTypeIndestructible,
+	"explosive":      domain.BlockTypeExplosive,
+	"item":           domain.BlockTypeItem,
+}
+
+// itemTypes maps item names to every registered item type.
+var itemTypes = func() map[string]domain.ItemType {
+	m := map[string]domain.ItemType{}
+	for _, t := range domain.ItemTypes() {
+		spec, _ := domain.ItemSpec(t)
+		m[spec.Name] = t
+	}
+	return m
+}()
+
+var gridTypes = map[rune]domain.BlockType{
+	'N': domain.BlockTypeNormal,
+	'H': domain.BlockTypeHard,
+	'X': domain.BlockTypeIndestructible,
+	'E': domain.BlockTypeExplosive,
+	'I': domain.BlockTypeItem,
+}
+
+var blockShapes = map[string]domain.BlockShape{
+	"":        domain.BlockShapeRect,
+	"rect":    domain.BlockShapeRect,
+	"circle":  domain.BlockShapeCircle,
+	"rotated": domain.BlockShapeRotatedRect,
+}
+
+// ApplyTo returns base with the level's screen, ball, paddle and drop overrides applied.
+// Apply it to the undifficultied base layout so difficulty scales still take effect.
+func (l *Level) ApplyTo(base domain.LayoutConfig) domain.LayoutConfig {
+	cfg := base
+	if l.Screen != nil {
+		setFloat(&cfg.ScreenW, l.Screen.Width)
+		setFloat(&cfg.ScreenH, l.Screen.Height)
+	}
+	if l.Ball != nil {
+		setFloat(&cfg.BallSpeed, l.Ball.Speed)
+		setFloat(&cfg.BallRadius, l.Ball.Radius)
+	}
+	if l.Paddle != nil {
+		setFloat(&cfg.PaddleWidth, l.Paddle.Width)
+		setFloat(&cfg.PaddleSpeed, l.Paddle.Speed)
+	}
+	if l.Drops != nil {
+		cfg.Drops = l.Drops.apply(base.Drops)
+		if len(l.Drops.Blocks) > 0 {
+			cfg.BlockDrops = make(map[domain.BlockType]domain.DropTable, len(base.BlockDrops)+len(l.Drops.Blocks))
+			for t, table := range base.BlockDrops {
+				cfg.BlockDrops[t] = table
+			}
+			for name, spec := range l.Drops.Blocks {
+				t := blockTypes[name]
+				table, ok := base.BlockDrops[t]
+				if !ok {
+					table = cfg.Drops
+				}
+				cfg.BlockDrops[t] = spec.apply(table)
+			}
+		}
+	}
+	return cfg
+}
+
+func setFloat(dst *float64, v *float64) {
+	if v != nil {
+		*dst = *v
+	}
+}
+
+// BuildBlocks builds the level's blocks, scaled by the layout's difficulty BlockSizeScale.
+func (l *Level) BuildBlocks(layout domain.LayoutConfig) []domain.Block {
+	placed := l.scaledBlocks(layout.BlockSizeScale)
+	blocks := make([]domain.Block, len(placed))
+	for i, p := range placed {
+		blocks[i] = p.block
+	}
+	return blocks
+}
+
+// scaledBlocks is placedBlocks with the difficulty BlockSizeScale applied, i.e. the
+// geometry BuildBlocks hands to the game and Validate checks.
+func (l *Level) scaledBlocks(scale float64) []placedBlock {
+	placed := l.placedBlocks()
+	blocks := make([]domain.Block, len(placed))
+	for i, p := range placed {
+		blocks[i] = p.block
+	}
+	domain.ScaleBlocks(blocks, scale)
+	for i := range placed {
+		placed[i].block = blocks[i]
+	}
+	return placed
+}
+
+// placedBlock is a built block together with the JSON path it came from.
+type placedBlock struct {
+	block domain.Block
+	path  string
+	note  string // extra location detail, e.g. the grid column
+}
+
+// placedBlocks builds blocks from the grid and the block list, skipping entries
+// that do not name a known type or shape (those are reported by validation).
+func (l *Level) placedBlocks() []placedBlock {
+	var placed []placedBlock
+	if g := l.Grid; g != nil {
+		for r, row := range g.Rows {
+			for c, ch := range []rune(row) {
+				t, ok := gridTypes[ch]
+				if !ok {
+					continue
+				}
+				x := g.X + float64(c)*(g.BlockW+g.Spacing)
+				y := g.Y + float64(r)*(g.BlockH+g.Spacing)
+				placed = append(placed, placedBlock{
+					block: domain.NewBlock(x, y, g.BlockW, g.BlockH, t),
+					path:  fmt.Sprintf("grid.rows[%d]", r),
+					note:  fmt.Sprintf("column %d", c),
+				})
+			}
+		}
+	}
+	for i, spec := range l.Blocks {
+		t, okType := blockTypes[spec.typeName()]
+		shape, okShape := blockShapes[spec.Shape]
+		if !okType || !okShape {
+			continue
+		}
+		b := domain.NewBlock(spec.X, spec.Y, spec.W, spec.H, t)
+		b.Shape = shape
+		b.Angle = spec.Angle * math.Pi / 180
+		if spec.HP != nil && b.Destructible() {
+			b.HP = *spec.HP
+			b.MaxHP = *spec.HP
+		}
+		placed = append(placed, placedBlock{block: b, path: fmt.Sprintf("blocks[%d]", i)})
+	}
+	return placed
+}
+
+func (s BlockSpec) typeName() string {
+	if s.Type == "" {
+		return "normal"
+	}
+	return s.Type
+}
@@ -0,0 +1,403 @@
+package level
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"block-game/pkg/domain"
+)
+
+// Error is a problem in a level file, located by line and JSON field path.
+type Error struct {
+	File  string
+	Line  int
+	Field string // e.g. "blocks[3].hp"; empty for file-level errors
+	Msg   string
+}
+
+func (e *Error) Error() string {
+	loc := e.File
+	if e.Line > 0 {
+		loc = fmt.Sprintf("%s:%d", loc, e.Line)
+	}
+	if e.Field == "" {
+		return fmt.Sprintf("%s: %s", loc, e.Msg)
+	}
+	return fmt.Sprintf("%s: %s: %s", loc, e.Field, e.Msg)
+}
+
+// LoadFile reads, parses and validates the level at path against base.
+func LoadFile(path string, base domain.LayoutConfig) (*Level, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Load(filepath.Base(path), data, base)
+}
+
+// Load parses data and validates the result against base with the level's overrides applied.
+// name is only used in error messages.
+func Load(name string, data []byte, base domain.LayoutConfig) (*Level, error) {
+	lvl, err := Parse(name, data)
+	if err != nil {
+		return nil, err
+	}
+	if err := lvl.Validate(lvl.ApplyTo(base)); err != nil {
+		return nil, err
+	}
+	return lvl, nil
+}
+
+// Parse decodes a level and checks everything that does not depend on a layout:
+// syntax, unknown fields, block types, shapes, sizes, hit points and drop tables.
+// All problems found are returned together, joined with errors.Join.
+func Parse(name string, data []byte) (*Level, error) {
+	src := newSource(name, data)
+
+	var lvl Level
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&lvl); err != nil {
+		return nil, src.decodeError(err)
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, src.errorAt(dec.InputOffset(), "", "unexpected data after the level object")
+	}
+	lvl.src = src
+
+	if err := errors.Join(lvl.check()...); err != nil {
+		return nil, err
+	}
+	return &lvl, nil
+}
+
+// check validates the level on its own.
+func (l *Level) check() []error {
+	var errs []error
+	fail := func(path, format string, args ...any) {
+		errs = append(errs, l.src.errorFor(path, fmt.Sprintf(format, args...)))
+	}
+	positive := func(path string, v *float64) {
+		if v != nil && !(*v > 0) {
+			fail(path, "must be positive, got %g", *v)
+		}
+	}
+	chance := func(path string, v *float64) {
+		if v != nil && !(*v >= 0 && *v <= 1) {
+			fail(path, "must be between 0 and 1, got %g", *v)
+		}
+	}
+
+	if l.Screen != nil {
+		positive("screen.width", l.Screen.Width)
+		positive("screen.height", l.Screen.Height)
+	}
+	if l.Ball != nil {
+		positive("ball.speed", l.Ball.Speed)
+		positive("ball.radius", l.Ball.Radius)
+	}
+	if l.Paddle != nil {
+		positive("paddle.width", l.Paddle.Width)
+		positive("paddle.speed", l.Paddle.Speed)
+	}
+	dropTable := func(path string, d DropTableSpec) {
+		chance(path+".chance", d.Chance)
+		for _, name := range sortedKeys(d.Weights) {
+			if w := d.Weights[name]; !(w >= 0) || math.IsInf(w, 0) {
+				fail(path+".weights."+name, "must be a non-negative number, got %g", w)
+			}
+			if _, ok := itemTypes[name]; !ok {
+				fail(path+".weights."+name, "unknown item %q (want one of %s)", name, keys(itemTypes))
+			}
+		}
+	}
+	if l.Drops != nil {
+		dropTable("drops", l.Drops.DropTableSpec)
+		for _, name := range sortedKeys(l.Drops.Blocks) {
+			d := l.Drops.Blocks[name]
+			if _, ok := blockTypes[name]; !ok {
+				fail("drops.blocks."+name, "unknown block type %q (want one of %s)", name, keys(blockTypes))
+				continue
+			}
+			dropTable("drops.blocks."+name, d)
+		}
+	}
+
+	if g := l.Grid; g != nil {
+		if !(g.BlockW > 0) {
+			fail("grid.blockW", "must be positive, got %g", g.BlockW)
+		}
+		if !(g.BlockH > 0) {
+			fail("grid.blockH", "must be positive, got %g", g.BlockH)
+		}
+		if g.Spacing < 0 {
+			fail("grid.spacing", "must not be negative, got %g", g.Spacing)
+		}
+		for r, row := range g.Rows {
+			for c, ch := range []rune(row) {
+				if _, ok := gridTypes[ch]; !ok && ch != '.' && ch != ' ' {
+					fail(fmt.Sprintf("grid.rows[%d]", r), "column %d: unknown block %q (want one of %s)", c, ch, gridLegend())
+				}
+			}
+		}
+	}
+
+	for i, b := range l.Blocks {
+		path := fmt.Sprintf("blocks[%d]", i)
+		if !(b.W > 0) {
+			fail(path+".w", "must be positive, got %g", b.W)
+		}
+		if !(b.H > 0) {
+			fail(path+".h", "must be positive, got %g", b.H)
+		}
+		t, ok := blockTypes[b.typeName()]
+		if !ok {
+			fail(path+".type", "unknown block type %q (want one of %s)", b.Type, keys(blockTypes))
+		}
+		if _, ok := blockShapes[b.Shape]; !ok {
+			fail(path+".shape", "unknown shape %q (want one of %s)", b.Shape, keys(blockShapes))
+		}
+		if math.IsNaN(b.Angle) || math.IsInf(b.Angle, 0) {
+			fail(path+".angle", "must be finite")
+		}
+		if b.HP != nil {
+			switch {
+			case ok && t == domain.BlockTypeIndestructible:
+				fail(path+".hp", "indestructible blocks have no hit points")
+			case *b.HP < 1:
+				fail(path+".hp", "must be at least 1, got %d", *b.HP)
+			}
+		}
+	}
+
+	if l.Grid == nil && len(l.Blocks) == 0 {
+		fail("", "level has no blocks")
+	}
+	return errs
+}
+
+// Validate checks the level against a layout (normally ApplyTo of the base layout):
+// every block must lie inside the screen, stay MinPaddleGap above the paddle,
+// and not overlap another block. Shaped blocks are checked by their bounding box,
+// after the layout's BlockSizeScale, as BuildBlocks places them.
+func (l *Level) Validate(cfg domain.LayoutConfig) error {
+	var errs []error
+	placed := l.scaledBlocks(cfg.BlockSizeScale)
+	destructible := 0
+	floor := cfg.PaddleY - cfg.MinPaddleGap
+	for i, p := range placed {
+		fail := func(format string, args ...any) {
+			msg := fmt.Sprintf(format, args...)
+			if p.note != "" {
+				msg = p.note + ": " + msg
+			}
+			errs = append(errs, l.src.errorFor(p.path, msg))
+		}
+		if p.block.Destructible() {
+			destructible++
+		}
+		x, y, w, h := p.block.Bounds()
+		if x < 0 || y < 0 || x+w > cfg.ScreenW || y+h > cfg.ScreenH {
+			fail("block (%g,%g %gx%g) is outside the %gx%g screen", x, y, w, h, cfg.ScreenW, cfg.ScreenH)
+			continue
+		}
+		if y+h > floor {
+			fail("block bottom %g is below %g (paddle y %g minus gap %g)", y+h, floor, cfg.PaddleY, cfg.MinPaddleGap)
+		}
+		for _, other := range placed[:i] {
+			ox, oy, ow, oh := other.block.Bounds()
+			if x < ox+ow && x+w > ox && y < oy+oh && y+h > oy {
+				where := other.path
+				if other.note != "" {
+					where += " " + other.note
+				}
+				fail("overlaps the block at %s", where)
+				break
+			}
+		}
+	}
+	if len(placed) > 0 && destructible == 0 {
+		errs = append(errs, l.src.errorFor("", "level has no destructible blocks and can never be cleared"))
+	}
+	return errors.Join(errs...)
+}
+
+// sortedKeys returns the names of m in order, so problems are reported in a stable order.
+func sortedKeys[V any](m map[string]V) []string {
+	names := make([]string, 0, len(m))
+	for k := range m {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// keys lists a lookup table's names for error messages, skipping the empty default.
+func keys[V any](m map[string]V) string {
+	names := make([]string, 0, len(m))
+	for k := range m {
+		if k != "" {
+			names = append(names, fmt.Sprintf("%q", k))
+		}
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+func gridLegend() string {
+	chars := make([]string, 0, len(gridTypes)+1)
+	for ch := range gridTypes {
+		chars = append(chars, string(ch))
+	}
+	sort.Strings(chars)
+	return strings.Join(append(chars, "."), " ")
+}
+
+// source maps JSON field paths of a level file to byte offsets and lines.
+type source struct {
+	name    string
+	data    []byte
+	offsets map[string]int64 // path -> offset where its value starts
+}
+
+func newSource(name string, data []byte) *source {
+	s := &source{name: name, data: data, offsets: map[string]int64{}}
+	s.index()
+	return s
+}
+
+// index walks the JSON tokens and records where each value starts.
+// It gives up quietly on malformed input; Decode reports those errors.
+func (s *source) index() {
+	dec := json.NewDecoder(bytes.NewReader(s.data))
+	var walk func(path string) error
+	walk = func(path string) error {
+		s.offsets[path] = s.skipSpace(dec.InputOffset())
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		switch tok {
+		case json.Delim('{'):
+			for dec.More() {
+				key, err := dec.Token()
+				if err != nil {
+					return err
+				}
+				name, _ := key.(string)
+				if path != "" {
+					name = path + "." + name
+				}
+				if err := walk(name); err != nil {
+					return err
+				}
+			}
+			_, err = dec.Token()
+		case json.Delim('['):
+			for i := 0; dec.More(); i++ {
+				if err := walk(fmt.Sprintf("%s[%d]", path, i)); err != nil {
+					return err
+				}
+			}
+			_, err = dec.Token()
+		}
+		return err
+	}
+	_ = walk("")
+}
+
+// skipSpace moves an offset past whitespace and separators to the next token.
+func (s *source) skipSpace(off int64) int64 {
+	for off < int64(len(s.data)) && strings.IndexByte(" \t\r\n:,", s.data[off]) >= 0 {
+		off++
+	}
+	return off
+}
+
+// line returns the 1-based line of a byte offset.
+func (s *source) line(off int64) int {
+	if off > int64(len(s.data)) {
+		off = int64(len(s.data))
+	}
+	return bytes.Count(s.data[:off], []byte("\n")) + 1
+}
+
+func (s *source) errorAt(off int64, field, msg string) *Error {
+	return &Error{File: s.name, Line: s.line(off), Field: field, Msg: msg}
+}
+
+// errorFor reports msg at path, falling back to the nearest enclosing value that was indexed.
+func (s *source) errorFor(path, msg string) *Error {
+	for p := path; ; {
+		if off, ok := s.offsets[p]; ok {
+			return s.errorAt(off, path, msg)
+		}
+		i := strings.LastIndexAny(p, ".[")
+		if i < 0 {
+			if p == "" {
+				return &Error{File: s.name, Field: path, Msg: msg}
+			}
+			p = ""
+			continue
+		}
+		p = p[:i]
+	}
+}
+
+// decodeError converts an encoding/json error into a located Error.
+func (s *source) decodeError(err error) error {
+	var syntax *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntax):
+		return s.errorAt(syntax.Offset, "", err.Error())
+	case errors.As(err, &typeErr):
+		return s.errorAt(typeErr.Offset, fieldPath(typeErr.Field), fmt.Sprintf("cannot use %s as %s", typeErr.Value, typeErr.Type))
+	case errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF):
+		return s.errorAt(int64(len(s.data)), "", "unexpected end of file")
+	}
+	if name, ok := strings.CutPrefix(err.Error(), "json: unknown field "); ok {
+		name = strings.Trim(name, `"`)
+		return s.unknownField(name)
+	}
+	return &Error{File: s.name, Msg: err.Error()}
+}
+
+// fieldPath rewrites encoding/json's dotted field ("blocks.0.x") into the path style used here ("blocks[0].x").
+func fieldPath(field string) string {
+	var b strings.Builder
+	for i, part := range strings.Split(field, ".") {
+		switch {
+		case part != "" && strings.Trim(part, "0123456789") == "":
+			b.WriteString("[" + part + "]")
+		case i > 0:
+			b.WriteString("." + part)
+		default:
+			b.WriteString(part)
+		}
+	}
+	return b.String()
+}
+
+// unknownField locates the first use of a field name the format does not define.
+func (s *source) unknownField(name string) *Error {
+	best, bestOff := "", int64(-1)
+	for path, off := range s.offsets {
+		last := path[strings.LastIndexAny(path, ".")+1:]
+		if last == name && (bestOff < 0 || off < bestOff) {
+			best, bestOff = path, off
+		}
+	}
+	if bestOff < 0 {
+		return &Error{File: s.name, Field: name, Msg: "unknown field"}
+	}
+	return s.errorAt(bestOff, best, "unknown field")
+}
@@ -0,0 +1,235 @@
+package level
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"block-game/pkg/domain"
+)
+
+func testLayout() domain.LayoutConfig {
+	return domain.LayoutConfig{
+		ScreenW:      800,
+		ScreenH:      600,
+		BlockW:       70,
+		BlockH:       30,
+		PaddleWidth:  100,
+		PaddleHeight: 20,
+		PaddleY:      550,
+		BallRadius:   10,
+		BallSpeed:    300,
+		MinPaddleGap: 180,
+	}
+}
+
+func TestParseBuildsGridAndExplicitBlocks(t *testing.T) {
+	data := `{
+  "name": "mixed",
+  "grid": {"x": 10, "y": 20, "blockW": 50, "blockH": 20, "spacing": 5, "rows": ["N.H", "XE"]},
+  "blocks": [
+    {"x": 300, "y": 100, "w": 40, "h": 40, "shape": "circle", "type": "item"},
+    {"x": 400, "y": 100, "w": 60, "h": 20, "shape": "rotated", "angle": 90, "type": "hard", "hp": 5}
+  ]
+}`
+	lvl, err := Load("mixed.json", []byte(data), testLayout())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	blocks := lvl.BuildBlocks(testLayout())
+	if len(blocks) != 6 {
+		t.Fatalf("expected 6 blocks, got %d", len(blocks))
+	}
+
+	hard := blocks[1]
+	if hard.Type != domain.BlockTypeHard || hard.X != 120 || hard.Y != 20 || hard.W != 50 {
+		t.Fatalf("unexpected grid block: %+v", hard)
+	}
+	explosive := blocks[3]
+	if explosive.Type != domain.BlockTypeExplosive || explosive.X != 65 || explosive.Y != 45 {
+		t.Fatalf("unexpected second-row block: %+v", explosive)
+	}
+	circle := blocks[4]
+	if circle.Shape != domain.BlockShapeCircle || circle.Type != domain.BlockTypeItem {
+		t.Fatalf("unexpected circle block: %+v", circle)
+	}
+	rotated := blocks[5]
+	if rotated.Shape != domain.BlockShapeRotatedRect || rotated.HP != 5 || rotated.MaxHP != 5 {
+		t.Fatalf("unexpected rotated block: %+v", rotated)
+	}
+	if d := rotated.Angle - 1.5707963; d > 1e-6 || d < -1e-6 {
+		t.Fatalf("angle should be converted to radians, got %v", rotated.Angle)
+	}
+}
+
+func TestApplyToOverridesOnlyGivenFields(t *testing.T) {
+	data := `{"screen": {"width": 640}, "ball": {"speed": 250}, "paddle": {"width": 80},
+"drops": {"weights": {"multiball": 2}, "blocks": {"hard": {"chance": 1}}}, "grid": {"blockW": 70, "blockH": 30, "rows": ["N"]}}`
+	lvl, err := Parse("over.json", []byte(data))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	base := testLayout()
+	base.Drops = domain.DropTable{Chance: 0.1}
+	cfg := lvl.ApplyTo(base)
+
+	if cfg.ScreenW != 640 || cfg.ScreenH != 600 {
+		t.Fatalf("unexpected screen %vx%v", cfg.ScreenW, cfg.ScreenH)
+	}
+	if cfg.BallSpeed != 250 || cfg.BallRadius != 10 || cfg.PaddleWidth != 80 {
+		t.Fatalf("unexpected ball/paddle overrides: %+v", cfg)
+	}
+	wantDrops := domain.DropTable{Chance: 0.1, Weights: map[domain.ItemType]float64{domain.ItemTypeMultiball: 2}}
+	if !reflect.DeepEqual(cfg.Drops, wantDrops) {
+		t.Fatalf("unexpected drops %+v", cfg.Drops)
+	}
+	wantHard := wantDrops
+	wantHard.Chance = 1
+	if !reflect.DeepEqual(cfg.BlockDrops, map[domain.BlockType]domain.DropTable{domain.BlockTypeHard: wantHard}) {
+		t.Fatalf("unexpected block drops %+v", cfg.BlockDrops)
+	}
+	if base.Drops.Weights != nil || base.BlockDrops != nil {
+		t.Fatalf("base layout was modified: %+v", base.Drops)
+	}
+}
+
+func TestBuildBlocksAppliesDifficultyScale(t *testing.T) {
+	lvl, err := Parse("s.json", []byte(`{"blocks": [{"x": 100, "y": 100, "w": 60, "h": 20}]}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cfg := testLayout()
+	cfg.BlockSizeScale = 0.5
+	b := lvl.BuildBlocks(cfg)[0]
+	if b.W != 30 || b.H != 10 || b.X != 115 || b.Y != 105 {
+		t.Fatalf("block should shrink around its centre, got %+v", b)
+	}
+}
+
+func TestLoadReportsLineAndField(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want []string
+	}{
+		{
+			name: "syntax error",
+			data: "{\n  \"name\": \"x\",\n  \"blocks\": [\n    {\"x\": 1,}\n  ]\n}",
+			want: []string{"bad.json:4:"},
+		},
+		{
+			name: "wrong value type",
+			data: "{\n  \"blocks\": [\n    {\"x\": \"left\", \"y\": 1, \"w\": 1, \"h\": 1}\n  ]\n}",
+			want: []string{"bad.json:3: blocks[0].x: cannot use string"},
+		},
+		{
+			name: "unknown field",
+			data: "{\n  \"grid\": {\"blockW\": 70, \"blockH\": 30, \"rows\": [\"N\"]},\n  \"blocks\": [],\n  \"colour\": \"red\"\n}",
+			want: []string{"bad.json:4: colour: unknown field"},
+		},
+		{
+			name: "bad type, hp and size",
+			data: `{
+  "blocks": [
+    {"x": 0, "y": 0, "w": 70, "h": 30},
+    {"x": 100, "y": 0, "w": 70, "h": 30,
+     "type": "glass"},
+    {"x": 200, "y": 0, "w": 0, "h": 30,
+     "hp": 0}
+  ]
+}`,
+			want: []string{
+				`bad.json:5: blocks[1].type: unknown block type "glass"`,
+				"bad.json:6: blocks[2].w: must be positive",
+				"bad.json:7: blocks[2].hp: must be at least 1",
+			},
+		},
+		{
+			name: "grid legend",
+			data: "{\"grid\": {\"blockW\": 70, \"blockH\": 30,\n  \"rows\": [\n    \"NN\",\n    \"N?\"\n  ]}}",
+			want: []string{`bad.json:4: grid.rows[1]: column 1: unknown block '?'`},
+		},
+		{
+			name: "drop tables",
+			data: `{
+  "drops": {"chance": 1.5,
+    "weights": {"multiball": -1, "magnet": 1},
+    "blocks": {"glass": {}, "hard": {
+      "chance": -0.5}}},
+  "grid": {"blockW": 70, "blockH": 30, "rows": ["N"]}
+}`,
+			want: []string{
+				"bad.json:2: drops.chance: must be between 0 and 1",
+				`bad.json:3: drops.weights.magnet: unknown item "magnet"`,
+				"bad.json:3: drops.weights.multiball: must be a non-negative number",
+				`bad.json:4: drops.blocks.glass: unknown block type "glass"`,
+				"bad.json:5: drops.blocks.hard.chance: must be between 0 and 1",
+			},
+		},
+		{
+			name: "outside screen and below paddle gap",
+			data: "{\n  \"blocks\": [\n    {\"x\": 780, \"y\": 0, \"w\": 70, \"h\": 30},\n    {\"x\": 0, \"y\": 360, \"w\": 70, \"h\": 30}\n  ]\n}",
+			want: []string{
+				"bad.json:3: blocks[0]: block (780,0 70x30) is outside the 800x600 screen",
+				"bad.json:4: blocks[1]: block bottom 390 is below 370",
+			},
+		},
+		{
+			name: "overlap with grid",
+			data: "{\n  \"grid\": {\"blockW\": 70, \"blockH\": 30, \"rows\": [\"NN\"]},\n  \"blocks\": [\n    {\"x\": 60, \"y\": 10, \"w\": 20, \"h\": 20}\n  ]\n}",
+			want: []string{"bad.json:4: blocks[0]: overlaps the block at grid.rows[0] column 0"},
+		},
+		{
+			name: "never clearable",
+			data: `{"blocks": [{"x": 0, "y": 0, "w": 70, "h": 30, "type": "indestructible"}]}`,
+			want: []string{"level has no destructible blocks"},
+		},
+		{
+			name: "empty",
+			data: `{"name": "nothing"}`,
+			want: []string{"bad.json:1: level has no blocks"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load("bad.json", []byte(tt.data), testLayout())
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("error %q does not contain %q", err, want)
+				}
+			}
+			var lerr *Error
+			if !errors.As(err, &lerr) {
+				t.Errorf("expected a *level.Error, got %T", err)
+			}
+		})
+	}
+}
+
+func TestValidateUsesLevelScreenOverride(t *testing.T) {
+	data := `{"screen": {"width": 1000}, "blocks": [{"x": 900, "y": 0, "w": 70, "h": 30}]}`
+	if _, err := Load("wide.json", []byte(data), testLayout()); err != nil {
+		t.Fatalf("block fits the overridden screen: %v", err)
+	}
+}
+
+func TestValidateChecksScaledBlocks(t *testing.T) {
+	// blocks 10px apart fit at scale 1 but overlap once grown by half around their centres
+	lvl, err := Parse("s.json", []byte(`{"blocks": [{"x": 100, "y": 100, "w": 60, "h": 20}, {"x": 170, "y": 100, "w": 60, "h": 20}]}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cfg := testLayout()
+	if err := lvl.Validate(cfg); err != nil {
+		t.Fatalf("unscaled blocks are valid: %v", err)
+	}
+	cfg.BlockSizeScale = 1.5
+	if err := lvl.Validate(cfg); err == nil || !strings.Contains(err.Error(), "overlaps") {
+		t.Fatalf("expected the scaled blocks to overlap, got %v", err)
+	}
+}
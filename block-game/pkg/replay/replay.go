@@ -0,0 +1,181 @@
+// Package replay stores recorded runs: the settings a run started from and the input of every tick.
+package replay
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"block-game/pkg/domain"
+)
+
+// Version is the current replay file format. Version 2 runs on the PCG random streams,
+// version 3 drops items from drop tables, version 4 adds the laser and the fire input,
+// version 5 the catch item, version 6 the fireball, version 7 the harmful items,
+// version 8 the extra-life and barrier items, version 9 per-ball speeds, version 10
+// releases caught balls when the catch effect ends and version 11 counts every lost
+// life against the stage-clear bonus.
+const Version = 11
+
+// maxTicks bounds the tick count a replay may claim, about three days at 60 steps per
+// second, so that a corrupt header cannot make Decode allocate without limit.
+const maxTicks = 1 << 24
+
+// Mode tells playback how the run was started.
+type Mode string
+
+const (
+	ModeStage    Mode = "stage"    // one generated stage from Layout
+	ModeCampaign Mode = "campaign" // the bundled campaign; Layout is the base layout
+)
+
+// Replay is a recorded run. Inputs holds one entry per fixed simulation step.
+type Replay struct {
+	Version    int
+	Mode       Mode
+	Seed       int64
+	Difficulty domain.Difficulty
+	Layout     domain.LayoutConfig
+	Inputs     []domain.InputState
+}
+
+// file is the on-disk form; inputs are run-length encoded bitmasks.
+type file struct {
+	Version    int                 `json:"version"`
+	Mode       Mode                `json:"mode"`
+	Seed       int64               `json:"seed"`
+	Difficulty domain.Difficulty   `json:"difficulty"`
+	Layout     domain.LayoutConfig `json:"layout"`
+	Ticks      int                 `json:"ticks"`
+	Inputs     [][2]int            `json:"inputs"` // [mask, repeat count]
+}
+
+const (
+	bitMoveLeft = 1 << iota
+	bitMoveRight
+	bitLaunch
+	bitFire
+)
+
+func encodeInput(in domain.InputState) int {
+	mask := 0
+	if in.MoveLeft {
+		mask |= bitMoveLeft
+	}
+	if in.MoveRight {
+		mask |= bitMoveRight
+	}
+	if in.Launch {
+		mask |= bitLaunch
+	}
+	if in.Fire {
+		mask |= bitFire
+	}
+	return mask
+}
+
+func decodeInput(mask int) domain.InputState {
+	return domain.InputState{
+		MoveLeft:  mask&bitMoveLeft != 0,
+		MoveRight: mask&bitMoveRight != 0,
+		Launch:    mask&bitLaunch != 0,
+		Fire:      mask&bitFire != 0,
+	}
+}
+
+// Encode writes r to w.
+func Encode(w io.Writer, r *Replay) error {
+	f := file{
+		Version:    r.Version,
+		Mode:       r.Mode,
+		Seed:       r.Seed,
+		Difficulty: r.Difficulty,
+		Layout:     r.Layout,
+		Ticks:      len(r.Inputs),
+		Inputs:     [][2]int{},
+	}
+	for _, in := range r.Inputs {
+		mask := encodeInput(in)
+		if n := len(f.Inputs); n > 0 && f.Inputs[n-1][0] == mask {
+			f.Inputs[n-1][1]++
+			continue
+		}
+		f.Inputs = append(f.Inputs, [2]int{mask, 1})
+	}
+	return json.NewEncoder(w).Encode(f)
+}
+
+// Decode reads a replay written by Encode.
+func Decode(rd io.Reader) (*Replay, error) {
+	var f file
+	if err := json.NewDecoder(rd).Decode(&f); err != nil {
+		return nil, fmt.Errorf("decode replay: %w", err)
+	}
+	if f.Version != Version {
+		return nil, fmt.Errorf("unsupported replay version %d (want %d)", f.Version, Version)
+	}
+	switch f.Mode {
+	case ModeStage, ModeCampaign:
+	default:
+		return nil, fmt.Errorf("unknown replay mode %q", f.Mode)
+	}
+
+	if f.Ticks < 0 || f.Ticks > maxTicks {
+		return nil, fmt.Errorf("replay header has an implausible tick count %d", f.Ticks)
+	}
+	// Count before expanding, so the runs cannot allocate more than the header allows.
+	ticks := 0
+	for _, run := range f.Inputs {
+		if run[1] <= 0 {
+			return nil, errors.New("replay input run with a non-positive count")
+		}
+		if run[1] > maxTicks-ticks {
+			return nil, fmt.Errorf("replay has more than %d ticks of input, header says %d", maxTicks, f.Ticks)
+		}
+		ticks += run[1]
+	}
+	if ticks != f.Ticks {
+		return nil, fmt.Errorf("replay has %d ticks of input, header says %d", ticks, f.Ticks)
+	}
+
+	r := &Replay{
+		Version:    f.Version,
+		Mode:       f.Mode,
+		Seed:       f.Seed,
+		Difficulty: f.Difficulty,
+		Layout:     f.Layout,
+		Inputs:     make([]domain.InputState, 0, f.Ticks),
+	}
+	for _, run := range f.Inputs {
+		in := decodeInput(run[0])
+		for i := 0; i < run[1]; i++ {
+			r.Inputs = append(r.Inputs, in)
+		}
+	}
+	return r, nil
+}
+
+// Save writes r to path.
+func Save(path string, r *Replay) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := Encode(f, r); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Load reads a replay from path.
+func Load(path string) (*Replay, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Decode(f)
+}
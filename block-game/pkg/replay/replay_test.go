@@ -0,0 +1,73 @@
+package replay
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"block-game/pkg/domain"
+)
+
+func TestEncodeDecodeRoundTrip(t *testing.T) {
+	seed := int64(42)
+	r := &Replay{
+		Version:    Version,
+		Mode:       ModeStage,
+		Seed:       seed,
+		Difficulty: domain.DifficultyHard,
+		Layout: domain.LayoutConfig{
+			ScreenW:          800,
+			BallSpeed:        360.5,
+			BlockTypeWeights: map[domain.BlockType]float64{domain.BlockTypeHard: 0.25},
+			Drops:            domain.DropTable{Chance: 0.2, Weights: map[domain.ItemType]float64{domain.ItemTypeLaser: 1}},
+			Seed:             &seed,
+		},
+		Inputs: []domain.InputState{
+			{}, {}, {Launch: true},
+			{MoveLeft: true}, {MoveLeft: true}, {MoveLeft: true},
+			{MoveRight: true, Launch: true}, {MoveLeft: true, Fire: true},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := Encode(&buf, r); err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	if !strings.Contains(buf.String(), `"inputs":[[0,2],[4,1],[1,3],[6,1],[9,1]]`) {
+		t.Fatalf("inputs should be run-length encoded, got %s", buf.String())
+	}
+
+	got, err := Decode(&buf)
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if !reflect.DeepEqual(got, r) {
+		t.Fatalf("round trip mismatch:\n got %+v\nwant %+v", got, r)
+	}
+}
+
+func TestDecodeRejectsBadFiles(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{"version", `{"version": 99, "mode": "stage"}`, "unsupported replay version"},
+		{"mode", `{"version": 11, "mode": "arcade"}`, "unknown replay mode"},
+		{"tick count", `{"version": 11, "mode": "stage", "ticks": 5, "inputs": [[0, 2]]}`, "header says 5"},
+		{"run count", `{"version": 11, "mode": "stage", "ticks": 0, "inputs": [[0, 0]]}`, "non-positive count"},
+		{"negative ticks", `{"version": 11, "mode": "stage", "ticks": -1}`, "implausible tick count"},
+		{"huge ticks", `{"version": 11, "mode": "stage", "ticks": 1000000000000}`, "implausible tick count"},
+		{"huge run", `{"version": 11, "mode": "stage", "ticks": 5, "inputs": [[0, 1000000000000]]}`, "header says 5"},
+		{"syntax", `{"version": 11,`, "decode replay"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Decode(strings.NewReader(tt.data))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+// Package save stores suspended games: the full simulation state, the layout it runs on
+// and the exact position of its random source, so that a resumed game continues exactly
+// as it would have without the interruption.
+package save
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"block-game/pkg/domain"
+)
+
+// Version is the current save file format.
+const Version = 12
+
+// Game is a suspended game.
+type Game struct {
+	Version    int                 `json:"version"`
+	Difficulty domain.Difficulty   `json:"difficulty"`
+	Layout     domain.LayoutConfig `json:"layout"` // the layout of the running stage, ramp applied
+	State      domain.GameState    `json:"state"`
+	Random     domain.RandomState  `json:"random"`             // root source, split again by later campaign stages
+	Items      domain.RandomState  `json:"items"`              // item drop stream of the running stage
+	Campaign   *Campaign           `json:"campaign,omitempty"` // nil for a single generated stage
+}
+
+// Campaign is the progress of a suspended campaign run.
+type Campaign struct {
+	Stage      int             `json:"stage"` // 0-based index of the running stage
+	Level      string          `json:"level"` // name of that stage's level, checked on resume
+	Upgrades   domain.Upgrades `json:"upgrades"`
+	StartScore int             `json:"start_score"` // score and lives when the stage began
+	StartLives int             `json:"start_lives"`
+	// SharedRandom is set when one random source runs through every stage; otherwise
+	// each later stage seeds its own from its layout.
+	SharedRandom bool `json:"shared_random"`
+}
+
+// Encode writes g to w.
+func Encode(w io.Writer, g *Game) error {
+	return json.NewEncoder(w).Encode(g)
+}
+
+// Decode reads a save written by Encode.
+func Decode(rd io.Reader) (*Game, error) {
+	var g Game
+	if err := json.NewDecoder(rd).Decode(&g); err != nil {
+		return nil, fmt.Errorf("decode save: %w", err)
+	}
+	if g.Version != Version {
+		return nil, fmt.Errorf("unsupported save version %d (want %d)", g.Version, Version)
+	}
+	if g.State.GameOver {
+		return nil, errors.New("save holds a finished game")
+	}
+	for i, b := range g.State.Balls {
+		if b.Speed <= 0 {
+			return nil, fmt.Errorf("save has ball %d without a speed", i)
+		}
+	}
+	if g.Campaign != nil && g.Campaign.Stage < 0 {
+		return nil, fmt.Errorf("save has invalid campaign stage %d", g.Campaign.Stage)
+	}
+	return &g, nil
+}
+
+// Save writes g to path.
+func Save(path string, g *Game) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := Encode(f, g); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Load reads a save from path.
+func Load(path string) (*Game, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Decode(f)
+}
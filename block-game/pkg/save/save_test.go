@@ -0,0 +1,99 @@
+package save
+
+import (
+	"bytes"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"block-game/pkg/domain"
+)
+
+func testGame() *Game {
+	seed := int64(3)
+	return &Game{
+		Version:    Version,
+		Difficulty: domain.DifficultyEasy,
+		Layout: domain.LayoutConfig{
+			ScreenW:          800,
+			BallSpeed:        0.1 + 0.2, // not exactly representable in decimal
+			BlockTypeWeights: map[domain.BlockType]float64{domain.BlockTypeHard: 0.25},
+			Seed:             &seed,
+		},
+		State: domain.GameState{
+			Blocks:  []domain.Block{domain.NewBlock(10, 20, 70, 30, domain.BlockTypeHard)},
+			Balls:   []domain.Ball{{X: 1.0 / 3, Y: 200, VX: -123.456789, VY: 1e-9, Radius: 8, Speed: 123.456789}},
+			Paddle:  domain.Paddle{X: 350, Y: 550, Width: 300, Height: 20, BaseWidth: 100},
+			Items:   []domain.Item{{X: 5, Y: 6, Width: 16, Height: 12, VY: 180, Active: true, Type: domain.ItemTypePaddleEnlarge}},
+			Effects: []domain.ActiveEffect{{Kind: domain.EffectPaddleEnlarge, RemainingTicks: 42, Stacks: 1, Magnitude: 3}},
+			Score:   17,
+			Lives:   2,
+			Tick:    900,
+			Stats:   domain.Stats{BallsLost: 1, ItemsDropped: 2},
+		},
+		Random: domain.RandomState{State: 0xfedcba9876543210, Inc: 1},
+		Items:  domain.RandomState{State: 1 << 63, Inc: 5},
+		Campaign: &Campaign{
+			Stage:        1,
+			Level:        "Hard Shell",
+			Upgrades:     domain.Upgrades{PaddleWidthBonus: 0.1},
+			StartScore:   10,
+			StartLives:   3,
+			SharedRandom: true,
+		},
+	}
+}
+
+func TestEncodeDecodeRoundTrip(t *testing.T) {
+	g := testGame()
+	var buf bytes.Buffer
+	if err := Encode(&buf, g); err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	got, err := Decode(&buf)
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if !reflect.DeepEqual(got, g) {
+		t.Fatalf("round trip mismatch:\n got %+v\nwant %+v", got, g)
+	}
+}
+
+func TestSaveLoadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "save.json")
+	g := testGame()
+	g.Campaign = nil
+	if err := Save(path, g); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	got, err := Load(path)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if !reflect.DeepEqual(got, g) {
+		t.Fatalf("file round trip mismatch")
+	}
+}
+
+func TestDecodeRejectsBadFiles(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{"version", `{"version": 99}`, "unsupported save version"},
+		{"finished", `{"version": 12, "state": {"GameOver": true}}`, "finished game"},
+		{"stage", `{"version": 12, "campaign": {"stage": -1}}`, "invalid campaign stage"},
+		{"ball speed", `{"version": 12, "state": {"Balls": [{"X": 1, "Y": 1, "Radius": 5}]}}`, "without a speed"},
+		{"syntax", `{"version": 12,`, "decode save"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Decode(strings.NewReader(tt.data))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}